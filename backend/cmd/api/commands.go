@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"awsome-shop/internal/config"
+	"awsome-shop/internal/repository"
+	"awsome-shop/internal/service"
+
+	"gorm.io/gorm"
+)
+
+// runCommand runs a maintenance subcommand instead of starting the server
+func runCommand(db *gorm.DB, cfg *config.Config, name string, args []string) error {
+	repos := repository.NewRepositories(db)
+	services := service.NewServices(repos, db, cfg)
+
+	switch name {
+	case "migrate":
+		// Migrations already ran during startup
+		log.Println("Migrations are up to date")
+		return nil
+	case "reconcile":
+		return runReconcile(services, args)
+	case "verify-ledger":
+		return runVerifyLedger(services)
+	default:
+		return fmt.Errorf("unknown command %q (available: migrate, reconcile, verify-ledger)", name)
+	}
+}
+
+// runReconcile checks every wallet and points balance against the ledger and optionally repairs mismatches
+// Usage: api reconcile [-repair]
+func runReconcile(services *service.Services, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "write correction transactions for mismatched balances")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := services.Reconciliation.Reconcile(*repair, nil)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if report.HasIssues() {
+		return errors.New("points ledger has unresolved issues")
+	}
+
+	log.Println("Points ledger is consistent")
+	return nil
+}
+
+// runVerifyLedger walks every user's points transaction hash chain and reports breaks
+// Usage: api verify-ledger
+func runVerifyLedger(services *service.Services) error {
+	report, err := services.Ledger.VerifyChains()
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+
+	if !report.Intact {
+		return fmt.Errorf("points ledger hash chain has %d breaks", len(report.Breaks))
+	}
+
+	log.Println("Points ledger hash chain is intact")
+	return nil
+}
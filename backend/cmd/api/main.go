@@ -38,6 +38,14 @@ func main() {
 	}
 	log.Println("Database health check passed")
 
+	// Run a maintenance subcommand instead of the server, e.g. "api reconcile -repair"
+	if len(os.Args) > 1 {
+		if err := runCommand(db, cfg, os.Args[1], os.Args[2:]); err != nil {
+			log.Fatalf("Command %s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	// Setup router
 	r := router.Setup(db, cfg)
 
@@ -1,14 +1,19 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the application
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
+	Server      ServerConfig
+	Database    DatabaseConfig
+	JWT         JWTConfig
+	Idempotency IdempotencyConfig
+	Anomaly     AnomalyConfig
+	Storage     StorageConfig
 }
 
 // ServerConfig holds server configuration
@@ -33,6 +38,34 @@ type JWTConfig struct {
 	ExpirationHours int
 }
 
+// IdempotencyConfig holds idempotency key configuration
+type IdempotencyConfig struct {
+	TTLHours  int // How long a stored response can be replayed
+	MaxBodyMB int // Largest request body buffered for a request with an Idempotency-Key
+}
+
+// AnomalyConfig holds thresholds for the points anomaly report
+type AnomalyConfig struct {
+	LargeGrantAmount       int // Grants of at least this amount are checked against later redemptions
+	RedemptionWindowHours  int // A redemption this soon after a large grant is flagged
+	WorkdayStartHour       int // Grants before this hour (local time) are outside working hours
+	WorkdayEndHour         int // Grants from this hour on are outside working hours
+	RoundNumberUnit        int // Amounts that are a multiple of this are round numbers
+	RoundNumberRepeatCount int // Flag an operator who grants round numbers to one user this many times
+	LookbackDays           int // Default report period when no range is given
+}
+
+// StorageConfig holds uploaded file storage configuration
+type StorageConfig struct {
+	Driver          string // Only "local" is supported for now
+	LocalDir        string // Root directory for the local driver
+	PublicBaseURL   string // URL prefix that stored files are served under
+	MaxUploadMB     int    // Largest accepted image upload
+	ThumbnailSize   int    // Thumbnails fit within a square of this many pixels
+	MaxImagePixels  int    // Largest accepted image width or height
+	CacheMaxAgeDays int    // Cache-Control max-age for served files
+}
+
 // Load loads configuration from file and environment variables
 func Load() (*Config, error) {
 	viper.SetConfigName("config")
@@ -45,6 +78,22 @@ func Load() (*Config, error) {
 	viper.SetDefault("server.mode", "debug")
 	viper.SetDefault("database.charset", "utf8mb4")
 	viper.SetDefault("jwt.expirehours", 24)
+	viper.SetDefault("idempotency.ttlhours", 24)
+	viper.SetDefault("idempotency.maxbodymb", 8)
+	viper.SetDefault("anomaly.largegrantamount", 1000)
+	viper.SetDefault("anomaly.redemptionwindowhours", 24)
+	viper.SetDefault("anomaly.workdaystarthour", 9)
+	viper.SetDefault("anomaly.workdayendhour", 18)
+	viper.SetDefault("anomaly.roundnumberunit", 100)
+	viper.SetDefault("anomaly.roundnumberrepeatcount", 3)
+	viper.SetDefault("anomaly.lookbackdays", 30)
+	viper.SetDefault("storage.driver", "local")
+	viper.SetDefault("storage.localdir", "./uploads")
+	viper.SetDefault("storage.publicbaseurl", "/api/v1/media")
+	viper.SetDefault("storage.maxuploadmb", 5)
+	viper.SetDefault("storage.thumbnailsize", 320)
+	viper.SetDefault("storage.maximagepixels", 8000)
+	viper.SetDefault("storage.cachemaxagedays", 365)
 
 	// Read from environment variables
 	viper.AutomaticEnv()
@@ -61,5 +110,9 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	if config.Storage.Driver != "local" {
+		return nil, fmt.Errorf("unsupported storage driver: %s", config.Storage.Driver)
+	}
+
 	return &config, nil
 }
@@ -3,6 +3,7 @@ package database
 import (
 	"fmt"
 	"log"
+	"time"
 
 	"awsome-shop/internal/config"
 	"awsome-shop/internal/models"
@@ -43,16 +44,143 @@ func Migrate(db *gorm.DB) error {
 		&models.RedemptionOrder{},
 		&models.PointsTransaction{},
 		&models.ProductPriceHistory{},
+		&models.IdempotencyKey{},
+		&models.WelcomeBonusPolicy{},
+		&models.WelcomeBonusRule{},
+		&models.Campaign{},
+		&models.PointsStatement{},
+		&models.UserWallet{},
+		&models.ManagerAllowance{},
+		&models.PointsGrantApproval{},
+		&models.UserSegment{},
+		&models.SegmentGrantJob{},
+		&models.ReasonCode{},
+		&models.Category{},
+		&models.Tag{},
+		&models.ProductVariant{},
+		&models.ProductImage{},
+		&models.ScheduledPriceChange{},
+		&models.InventoryMovement{},
+		&models.Notification{},
+		&models.DataMigration{},
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	if err := backfillUserWallets(db); err != nil {
+		return fmt.Errorf("failed to backfill user wallets: %w", err)
+	}
+
+	if err := runOnce(db, "seal_points_ledger", sealPointsLedger); err != nil {
+		return fmt.Errorf("failed to seal points ledger: %w", err)
+	}
+
+	if err := ensureProductSearchIndex(db); err != nil {
+		return fmt.Errorf("failed to create product search index: %w", err)
+	}
+
 	log.Println("Database migrations completed")
 	return nil
 }
 
+// backfillUserWallets moves balances from before wallets existed into the benefit wallet
+// Only users without any wallet row are touched, so running it again is a no-op.
+func backfillUserWallets(db *gorm.DB) error {
+	return db.Exec(`INSERT INTO user_wallets (user_id, wallet, balance, created_at, updated_at)
+		SELECT users.id, ?, users.points_balance, NOW(), NOW() FROM users
+		WHERE users.points_balance <> 0
+		AND NOT EXISTS (SELECT 1 FROM user_wallets WHERE user_wallets.user_id = users.id)`,
+		models.WalletBenefit).Error
+}
+
+// runOnce applies a one-time data fix unless data_migrations shows it has already run
+func runOnce(db *gorm.DB, name string, fix func(db *gorm.DB) error) error {
+	var count int64
+	err := db.Model(&models.DataMigration{}).Where("name = ?", name).Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if err := fix(db); err != nil {
+		return err
+	}
+
+	return db.Create(&models.DataMigration{Name: name, AppliedAt: time.Now()}).Error
+}
+
+// sealPointsLedger fills in the hash chain for transactions written before ledger hashing existed
+// Entries are hashed per user in ledger order; already hashed entries are left untouched.
+// It runs once (see runOnce): sealing on every startup would let anyone who blanks a hash in
+// the database have their edit sealed into a valid chain. Any unhashed entry found afterwards
+// is reported by ledger verification instead.
+func sealPointsLedger(db *gorm.DB) error {
+	var userIDs []uint
+	err := db.Model(&models.PointsTransaction{}).
+		Where("hash = ''").
+		Distinct("user_id").
+		Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return err
+	}
+
+	for _, userID := range userIDs {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var transactions []models.PointsTransaction
+			err := tx.Where("user_id = ?", userID).
+				Order("id ASC").
+				Find(&transactions).Error
+			if err != nil {
+				return err
+			}
+
+			prevHash := ""
+			for i := range transactions {
+				transaction := &transactions[i]
+				if transaction.Hash == "" {
+					transaction.PrevHash = prevHash
+					transaction.Hash = transaction.ComputeHash()
+					err := tx.Model(transaction).UpdateColumns(map[string]interface{}{
+						"prev_hash": transaction.PrevHash,
+						"hash":      transaction.Hash,
+					}).Error
+					if err != nil {
+						return err
+					}
+				}
+				prevHash = transaction.Hash
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(userIDs) > 0 {
+		log.Printf("Sealed points ledger for %d users", len(userIDs))
+	}
+	return nil
+}
+
+// ensureProductSearchIndex creates the FULLTEXT index used by catalog keyword search
+// GORM cannot declare a FULLTEXT index with a parser, so it is created here when missing.
+// The ngram parser splits text into overlapping character pairs, which makes Chinese
+// product names searchable without word segmentation.
+func ensureProductSearchIndex(db *gorm.DB) error {
+	if db.Migrator().HasIndex(&models.Product{}, "idx_products_search") {
+		return nil
+	}
+
+	return db.Exec(`ALTER TABLE products ADD FULLTEXT INDEX idx_products_search
+		(name_zh, name_en, short_description_zh, short_description_en, description_zh, description_en)
+		WITH PARSER ngram`).Error
+}
+
 // HealthCheck checks if the database connection is alive
 func HealthCheck(db *gorm.DB) error {
 	sqlDB, err := db.DB()
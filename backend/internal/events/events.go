@@ -0,0 +1,86 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// Event types
+const (
+	ProductPublished   = "product.published"   // A product's availability window opened
+	ProductUnpublished = "product.unpublished" // A product's availability window closed
+	ProductLowStock    = "product.low_stock"   // A product's stock fell to its low-stock threshold
+)
+
+// Event is something that happened in the shop, delivered to every subscriber of its type
+type Event struct {
+	Type       string
+	OccurredAt time.Time
+	Payload    interface{}
+}
+
+// ProductAvailabilityPayload is the payload of ProductPublished and ProductUnpublished events
+type ProductAvailabilityPayload struct {
+	ProductID   uint
+	ProductName string
+	Status      string // The product's status after the change
+}
+
+// ProductLowStockPayload is the payload of ProductLowStock events
+type ProductLowStockPayload struct {
+	ProductID     uint
+	ProductName   string
+	StockQuantity int
+	Threshold     int
+}
+
+// Handler receives published events
+type Handler func(event Event)
+
+// Bus delivers published events to subscribers synchronously, in subscription order
+// A subscriber that panics is logged and does not stop delivery to the others.
+type Bus struct {
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+}
+
+// NewBus creates a new Bus instance
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for events of eventType
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish delivers an event of eventType to its subscribers
+func (b *Bus) Publish(eventType string, payload interface{}) {
+	event := Event{
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		Payload:    payload,
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[eventType]
+	b.mu.RUnlock()
+
+	log.Printf("Event %s: %+v", event.Type, event.Payload)
+	for _, handler := range handlers {
+		deliver(handler, event)
+	}
+}
+
+// deliver calls handler, recovering from a panic so one subscriber cannot break the publisher
+func deliver(handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Event handler for %s panicked: %v", event.Type, r)
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminCampaignHandler handles admin campaign management requests
+type AdminCampaignHandler struct {
+	campaignService *service.CampaignService
+}
+
+// NewAdminCampaignHandler creates a new AdminCampaignHandler instance
+func NewAdminCampaignHandler(campaignService *service.CampaignService) *AdminCampaignHandler {
+	return &AdminCampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+// CampaignRequest represents a request to create or update a campaign
+type CampaignRequest struct {
+	Name            string    `json:"name" binding:"required"`
+	Description     string    `json:"description"`
+	StartsAt        time.Time `json:"starts_at" binding:"required"`
+	EndsAt          time.Time `json:"ends_at" binding:"required"`
+	RuleType        string    `json:"rule_type" binding:"required,oneof=discount bonus_points"`
+	DiscountPercent int       `json:"discount_percent" binding:"min=0"`
+	BonusPoints     int       `json:"bonus_points" binding:"min=0"`
+	AppliesToAll    bool      `json:"applies_to_all"`
+	ProductIDs      []uint    `json:"product_ids"`
+	CategoryIDs     []uint    `json:"category_ids"`
+}
+
+// toServiceRequest converts the handler request to a service request
+func (r *CampaignRequest) toServiceRequest() *service.CampaignRequest {
+	return &service.CampaignRequest{
+		Name:            r.Name,
+		Description:     r.Description,
+		StartsAt:        r.StartsAt,
+		EndsAt:          r.EndsAt,
+		RuleType:        r.RuleType,
+		DiscountPercent: r.DiscountPercent,
+		BonusPoints:     r.BonusPoints,
+		AppliesToAll:    r.AppliesToAll,
+		ProductIDs:      r.ProductIDs,
+		CategoryIDs:     r.CategoryIDs,
+	}
+}
+
+// CreateCampaign creates a new campaign
+// POST /api/v1/admin/campaigns
+func (h *AdminCampaignHandler) CreateCampaign(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	var req CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	campaign, err := h.campaignService.CreateCampaign(req.toServiceRequest(), operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"campaign": campaign,
+	})
+}
+
+// ListCampaigns lists campaigns with optional status filter
+// GET /api/v1/admin/campaigns?status=active
+func (h *AdminCampaignHandler) ListCampaigns(c *gin.Context) {
+	var status *string
+	if statusStr := c.Query("status"); statusStr != "" {
+		status = &statusStr
+	}
+
+	campaigns, err := h.campaignService.ListCampaigns(status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve campaigns",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaigns": campaigns,
+	})
+}
+
+// GetCampaign gets a campaign by ID
+// GET /api/v1/admin/campaigns/:id
+func (h *AdminCampaignHandler) GetCampaign(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid campaign ID",
+		})
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaignByID(uint(campaignID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign": campaign,
+	})
+}
+
+// UpdateCampaign replaces a campaign's settings and target products
+// PUT /api/v1/admin/campaigns/:id
+func (h *AdminCampaignHandler) UpdateCampaign(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid campaign ID",
+		})
+		return
+	}
+
+	var req CampaignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	campaign, err := h.campaignService.UpdateCampaign(uint(campaignID), req.toServiceRequest())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaign": campaign,
+	})
+}
+
+// SetCampaignStatusRequest represents a request to set campaign status
+type SetCampaignStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active inactive"`
+}
+
+// SetCampaignStatus sets a campaign's status (active/inactive)
+// PUT /api/v1/admin/campaigns/:id/status
+func (h *AdminCampaignHandler) SetCampaignStatus(c *gin.Context) {
+	campaignID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid campaign ID",
+		})
+		return
+	}
+
+	var req SetCampaignStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format. Status must be 'active' or 'inactive'",
+		})
+		return
+	}
+
+	err = h.campaignService.SetCampaignStatus(uint(campaignID), req.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Campaign status updated successfully",
+	})
+}
+
+// RegisterRoutes registers admin campaign routes
+func (h *AdminCampaignHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/campaigns")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.POST("", h.CreateCampaign)
+		admin.GET("", h.ListCampaigns)
+		admin.GET("/:id", h.GetCampaign)
+		admin.PUT("/:id", h.UpdateCampaign)
+		admin.PUT("/:id/status", h.SetCampaignStatus)
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminInventoryHandler handles admin requests for product stock movements
+type AdminInventoryHandler struct {
+	inventoryService *service.InventoryService
+}
+
+// NewAdminInventoryHandler creates a new AdminInventoryHandler instance
+func NewAdminInventoryHandler(inventoryService *service.InventoryService) *AdminInventoryHandler {
+	return &AdminInventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// InventoryMovementRequest represents a request to record a stock movement
+type InventoryMovementRequest struct {
+	VariantID      *uint  `json:"variant_id"`
+	MovementType   string `json:"movement_type" binding:"required,oneof=receive return writeoff adjust"`
+	Quantity       int    `json:"quantity" binding:"required"`
+	RelatedOrderID *uint  `json:"related_order_id"`
+	Note           string `json:"note"`
+}
+
+// RecordMovement receives, returns, writes off or adjusts a product's stock
+// POST /api/v1/admin/products/:id/inventory-movements
+func (h *AdminInventoryHandler) RecordMovement(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var req InventoryMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format. movement_type must be 'receive', 'return', 'writeoff' or 'adjust'",
+		})
+		return
+	}
+
+	movement, err := h.inventoryService.RecordMovement(uint(productID), &service.InventoryMovementRequest{
+		VariantID:      req.VariantID,
+		MovementType:   req.MovementType,
+		Quantity:       req.Quantity,
+		RelatedOrderID: req.RelatedOrderID,
+		Note:           req.Note,
+	}, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"movement": movement,
+	})
+}
+
+// ListMovements gets a product's stock history, newest first
+// GET /api/v1/admin/products/:id/inventory-movements?variant_id=&page=1&page_size=20
+func (h *AdminInventoryHandler) ListMovements(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var variantID *uint
+	if variantIDStr := c.Query("variant_id"); variantIDStr != "" {
+		id, err := strconv.ParseUint(variantIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid variant ID",
+			})
+			return
+		}
+		variantUID := uint(id)
+		variantID = &variantUID
+	}
+
+	// Get pagination parameters
+	page := 1
+	pageSize := 20
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= 100 {
+			pageSize = ps
+		}
+	}
+
+	movements, total, err := h.inventoryService.ListMovements(uint(productID), variantID, page, pageSize)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"movements": movements,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// RegisterRoutes registers admin inventory routes
+func (h *AdminInventoryHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/products")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.POST("/:id/inventory-movements", h.RecordMovement)
+		admin.GET("/:id/inventory-movements", h.ListMovements)
+	}
+}
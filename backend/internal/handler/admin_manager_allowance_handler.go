@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminManagerAllowanceHandler handles admin manager allowance requests
+type AdminManagerAllowanceHandler struct {
+	allowanceService *service.ManagerAllowanceService
+}
+
+// NewAdminManagerAllowanceHandler creates a new AdminManagerAllowanceHandler instance
+func NewAdminManagerAllowanceHandler(allowanceService *service.ManagerAllowanceService) *AdminManagerAllowanceHandler {
+	return &AdminManagerAllowanceHandler{
+		allowanceService: allowanceService,
+	}
+}
+
+// CreateAllowanceRequest represents a request to give a manager an allowance
+type CreateAllowanceRequest struct {
+	ManagerID uint      `json:"manager_id" binding:"required"`
+	Wallet    string    `json:"wallet" binding:"omitempty,oneof=benefit recognition"` // Defaults to recognition
+	Amount    int       `json:"amount" binding:"required,min=1"`
+	StartsAt  time.Time `json:"starts_at" binding:"required"`
+	EndsAt    time.Time `json:"ends_at" binding:"required"`
+	Note      string    `json:"note"`
+}
+
+// CreateAllowance gives a manager a points budget for their direct reports
+// POST /api/v1/admin/manager-allowances
+func (h *AdminManagerAllowanceHandler) CreateAllowance(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	var req CreateAllowanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	createReq := &service.CreateAllowanceRequest{
+		ManagerID: req.ManagerID,
+		Wallet:    req.Wallet,
+		Amount:    req.Amount,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Note:      req.Note,
+	}
+
+	allowance, err := h.allowanceService.CreateAllowance(createReq, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"allowance": allowance,
+	})
+}
+
+// ListAllowances lists manager allowances
+// GET /api/v1/admin/manager-allowances?manager_id=
+func (h *AdminManagerAllowanceHandler) ListAllowances(c *gin.Context) {
+	var managerID *uint
+	if managerIDStr := c.Query("manager_id"); managerIDStr != "" {
+		id, err := strconv.ParseUint(managerIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid manager ID",
+			})
+			return
+		}
+		uid := uint(id)
+		managerID = &uid
+	}
+
+	allowances, err := h.allowanceService.ListAllowances(managerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve allowances",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"allowances": allowances,
+	})
+}
+
+// SetAllowanceStatusRequest represents a request to set allowance status
+type SetAllowanceStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active inactive"`
+}
+
+// SetAllowanceStatus sets an allowance's status (active/inactive)
+// PUT /api/v1/admin/manager-allowances/:id/status
+func (h *AdminManagerAllowanceHandler) SetAllowanceStatus(c *gin.Context) {
+	allowanceID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid allowance ID",
+		})
+		return
+	}
+
+	var req SetAllowanceStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format. Status must be 'active' or 'inactive'",
+		})
+		return
+	}
+
+	err = h.allowanceService.SetAllowanceStatus(uint(allowanceID), req.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Allowance status updated successfully",
+	})
+}
+
+// RegisterRoutes registers admin manager allowance routes
+func (h *AdminManagerAllowanceHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/manager-allowances")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.POST("", h.CreateAllowance)
+		admin.GET("", h.ListAllowances)
+		admin.PUT("/:id/status", h.SetAllowanceStatus)
+	}
+}
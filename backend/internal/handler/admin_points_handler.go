@@ -3,31 +3,49 @@ package handler
 import (
 	"awsome-shop/internal/middleware"
 	"awsome-shop/internal/service"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // AdminPointsHandler handles admin points management requests
 type AdminPointsHandler struct {
-	pointsService *service.PointsService
+	pointsService         *service.PointsService
+	reconciliationService *service.ReconciliationService
+	ledgerService         *service.LedgerService
+	statementService      *service.StatementService
 }
 
 // NewAdminPointsHandler creates a new AdminPointsHandler instance
-func NewAdminPointsHandler(pointsService *service.PointsService) *AdminPointsHandler {
+func NewAdminPointsHandler(
+	pointsService *service.PointsService,
+	reconciliationService *service.ReconciliationService,
+	ledgerService *service.LedgerService,
+	statementService *service.StatementService,
+) *AdminPointsHandler {
 	return &AdminPointsHandler{
-		pointsService: pointsService,
+		pointsService:         pointsService,
+		reconciliationService: reconciliationService,
+		ledgerService:         ledgerService,
+		statementService:      statementService,
 	}
 }
 
 // GrantPointsRequest represents a request to grant points
 type GrantPointsRequest struct {
-	UserID uint   `json:"user_id" binding:"required"`
-	Amount int    `json:"amount" binding:"required,min=1"`
-	Reason string `json:"reason" binding:"required"`
+	UserID     uint   `json:"user_id" binding:"required"`
+	Wallet     string `json:"wallet" binding:"omitempty,oneof=benefit recognition"` // Defaults to benefit
+	Amount     int    `json:"amount" binding:"required,min=1"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"` // Required without a reason code, or when the code requires a note
 }
 
 // GrantPoints grants points to a user
+// Grants to admins are held for a second admin and answered with 202 Accepted.
 // POST /api/v1/admin/points/grant
 func (h *AdminPointsHandler) GrantPoints(c *gin.Context) {
 	operatorID, exists := middleware.GetUserID(c)
@@ -46,7 +64,7 @@ func (h *AdminPointsHandler) GrantPoints(c *gin.Context) {
 		return
 	}
 
-	err := h.pointsService.GrantPoints(req.UserID, req.Amount, req.Reason, operatorID)
+	approval, err := h.pointsService.GrantPoints(req.UserID, req.Wallet, req.Amount, req.ReasonCode, req.Reason, operatorID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -54,6 +72,14 @@ func (h *AdminPointsHandler) GrantPoints(c *gin.Context) {
 		return
 	}
 
+	if approval != nil {
+		c.JSON(http.StatusAccepted, gin.H{
+			"message":  "Grant to an admin is pending approval by another admin",
+			"approval": approval,
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"message": "Points granted successfully",
 	})
@@ -61,9 +87,11 @@ func (h *AdminPointsHandler) GrantPoints(c *gin.Context) {
 
 // DeductPointsRequest represents a request to deduct points
 type DeductPointsRequest struct {
-	UserID uint   `json:"user_id" binding:"required"`
-	Amount int    `json:"amount" binding:"required,min=1"`
-	Reason string `json:"reason" binding:"required"`
+	UserID     uint   `json:"user_id" binding:"required"`
+	Wallet     string `json:"wallet" binding:"omitempty,oneof=benefit recognition"` // Defaults to benefit
+	Amount     int    `json:"amount" binding:"required,min=1"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"` // Required without a reason code, or when the code requires a note
 }
 
 // DeductPoints deducts points from a user
@@ -85,7 +113,7 @@ func (h *AdminPointsHandler) DeductPoints(c *gin.Context) {
 		return
 	}
 
-	err := h.pointsService.DeductPoints(req.UserID, req.Amount, req.Reason, operatorID)
+	err := h.pointsService.DeductPoints(req.UserID, req.Wallet, req.Amount, req.ReasonCode, req.Reason, operatorID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -101,6 +129,9 @@ func (h *AdminPointsHandler) DeductPoints(c *gin.Context) {
 // BatchGrantPointsRequest represents a request to batch grant points
 type BatchGrantPointsRequest struct {
 	Markdown string `json:"markdown" binding:"required"`
+	Wallet   string `json:"wallet" binding:"omitempty,oneof=benefit recognition"` // Defaults to benefit
+	DryRun   bool   `json:"dry_run"`
+	Partial  bool   `json:"partial"`
 }
 
 // BatchGrantPoints grants points to multiple users
@@ -122,7 +153,333 @@ func (h *AdminPointsHandler) BatchGrantPoints(c *gin.Context) {
 		return
 	}
 
-	err := h.pointsService.BatchGrantPoints(req.Markdown, operatorID)
+	opts := service.BatchOptions{DryRun: req.DryRun, Partial: req.Partial}
+	result, err := h.pointsService.BatchGrantPoints(req.Markdown, req.Wallet, operatorID, opts)
+	if err != nil {
+		respondBatchError(c, err)
+		return
+	}
+
+	message := "Points granted to all users successfully"
+	if result.DryRun {
+		message = "Dry run completed, no points were granted"
+	} else if result.Failed > 0 {
+		message = "Points granted to valid rows, some rows failed"
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"result":  result,
+	})
+}
+
+// BatchDeductPointsRequest represents a request to batch deduct points
+type BatchDeductPointsRequest struct {
+	Markdown string `json:"markdown" binding:"required"`
+	Wallet   string `json:"wallet" binding:"omitempty,oneof=benefit recognition"` // Defaults to benefit
+}
+
+// BatchDeductPoints deducts points from multiple users
+// POST /api/v1/admin/points/batch-deduct
+func (h *AdminPointsHandler) BatchDeductPoints(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	var req BatchDeductPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	err := h.pointsService.BatchDeductPoints(req.Markdown, req.Wallet, operatorID)
+	if err != nil {
+		respondBatchError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Points deducted from all users successfully",
+	})
+}
+
+// ReverseTransactionRequest represents a request to reverse a points transaction
+type ReverseTransactionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ReverseTransaction reverses a points transaction with an equal-and-opposite entry
+// POST /api/v1/admin/points/transactions/:id/reverse
+func (h *AdminPointsHandler) ReverseTransaction(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	// Get transaction ID from URL
+	transactionIDStr := c.Param("id")
+	transactionID, err := strconv.ParseUint(transactionIDStr, 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid transaction ID",
+		})
+		return
+	}
+
+	var req ReverseTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	reversal, err := h.pointsService.ReverseTransaction(uint(transactionID), req.Reason, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"transaction": reversal,
+		"message":     "Transaction reversed successfully",
+	})
+}
+
+// ListTransactions searches the points ledger across all users
+// GET /api/v1/admin/points/transactions?user_id=&type=&wallet=&from=&to=&min_amount=&max_amount=&reason=&cursor=&limit=
+func (h *AdminPointsHandler) ListTransactions(c *gin.Context) {
+	var userID *uint
+	if userIDStr := c.Query("user_id"); userIDStr != "" {
+		uid, err := strconv.ParseUint(userIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid user ID",
+			})
+			return
+		}
+		id := uint(uid)
+		userID = &id
+	}
+
+	query, err := parsePointsHistoryQuery(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.pointsService.SearchPointsHistory(userID, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetReconciliationReport compares every balance with its ledger without changing anything
+// GET /api/v1/admin/points/reconciliation
+func (h *AdminPointsHandler) GetReconciliationReport(c *gin.Context) {
+	report, err := h.reconciliationService.Reconcile(false, nil)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to reconcile points ledger",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// RepairBalances reconciles the ledger and writes correction transactions for mismatched balances
+// POST /api/v1/admin/points/reconciliation/repair
+func (h *AdminPointsHandler) RepairBalances(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	report, err := h.reconciliationService.Reconcile(true, &operatorID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Points ledger reconciled",
+		"report":  report,
+	})
+}
+
+// VerifyLedger walks every user's transaction hash chain and reports tampered entries
+// GET /api/v1/admin/points/ledger/verify
+func (h *AdminPointsHandler) VerifyLedger(c *gin.Context) {
+	report, err := h.ledgerService.VerifyChains()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to verify points ledger",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// GenerateStatementsRequest represents a request to generate monthly statements
+type GenerateStatementsRequest struct {
+	Period string `json:"period"` // YYYY-MM, defaults to the previous month
+}
+
+// GenerateStatements stores statements for every employee for a closed month
+// POST /api/v1/admin/points/statements/generate
+func (h *AdminPointsHandler) GenerateStatements(c *gin.Context) {
+	// The body is optional
+	var req GenerateStatementsRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	period := req.Period
+	if period == "" {
+		period = service.PreviousPeriod(time.Now())
+	}
+
+	result, err := h.statementService.GenerateStatements(period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Points statements generated",
+		"result":  result,
+	})
+}
+
+// ListGrantApprovals lists grants to admins awaiting or after review
+// GET /api/v1/admin/points/approvals?status=pending
+func (h *AdminPointsHandler) ListGrantApprovals(c *gin.Context) {
+	var status *string
+	if statusStr := c.Query("status"); statusStr != "" {
+		status = &statusStr
+	}
+
+	approvals, err := h.pointsService.ListGrantApprovals(status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve grant approvals",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"approvals": approvals,
+	})
+}
+
+// ReviewGrantApprovalRequest represents a request to approve or reject a grant
+type ReviewGrantApprovalRequest struct {
+	Note string `json:"note"`
+}
+
+// parseGrantApprovalReview reads the approval ID and the optional review body
+func parseGrantApprovalReview(c *gin.Context) (uint, *ReviewGrantApprovalRequest, bool) {
+	approvalID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid approval ID",
+		})
+		return 0, nil, false
+	}
+
+	// The body is optional
+	var req ReviewGrantApprovalRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return 0, nil, false
+	}
+
+	return uint(approvalID), &req, true
+}
+
+// ApproveGrant applies a pending grant to an admin; the reviewer must be a different admin
+// POST /api/v1/admin/points/approvals/:id/approve
+func (h *AdminPointsHandler) ApproveGrant(c *gin.Context) {
+	reviewerID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	approvalID, req, ok := parseGrantApprovalReview(c)
+	if !ok {
+		return
+	}
+
+	approval, err := h.pointsService.ApproveGrant(approvalID, req.Note, reviewerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":  "Grant approved and points granted",
+		"approval": approval,
+	})
+}
+
+// RejectGrant rejects a pending grant to an admin
+// POST /api/v1/admin/points/approvals/:id/reject
+func (h *AdminPointsHandler) RejectGrant(c *gin.Context) {
+	reviewerID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	approvalID, req, ok := parseGrantApprovalReview(c)
+	if !ok {
+		return
+	}
+
+	approval, err := h.pointsService.RejectGrant(approvalID, req.Note, reviewerID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -131,7 +488,8 @@ func (h *AdminPointsHandler) BatchGrantPoints(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message": "Points granted to all users successfully",
+		"message":  "Grant rejected",
+		"approval": approval,
 	})
 }
 
@@ -143,5 +501,15 @@ func (h *AdminPointsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddlew
 		admin.POST("/grant", h.GrantPoints)
 		admin.POST("/deduct", h.DeductPoints)
 		admin.POST("/batch-grant", h.BatchGrantPoints)
+		admin.POST("/batch-deduct", h.BatchDeductPoints)
+		admin.GET("/transactions", h.ListTransactions)
+		admin.POST("/transactions/:id/reverse", h.ReverseTransaction)
+		admin.GET("/reconciliation", h.GetReconciliationReport)
+		admin.POST("/reconciliation/repair", h.RepairBalances)
+		admin.GET("/ledger/verify", h.VerifyLedger)
+		admin.POST("/statements/generate", h.GenerateStatements)
+		admin.GET("/approvals", h.ListGrantApprovals)
+		admin.POST("/approvals/:id/approve", h.ApproveGrant)
+		admin.POST("/approvals/:id/reject", h.RejectGrant)
 	}
 }
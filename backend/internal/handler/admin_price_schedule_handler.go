@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminPriceScheduleHandler handles admin requests for scheduled price changes
+type AdminPriceScheduleHandler struct {
+	priceScheduleService *service.PriceScheduleService
+}
+
+// NewAdminPriceScheduleHandler creates a new AdminPriceScheduleHandler instance
+func NewAdminPriceScheduleHandler(priceScheduleService *service.PriceScheduleService) *AdminPriceScheduleHandler {
+	return &AdminPriceScheduleHandler{
+		priceScheduleService: priceScheduleService,
+	}
+}
+
+// ScheduledPriceChangeRequest represents a request to schedule a price change
+type ScheduledPriceChangeRequest struct {
+	ProductID      uint       `json:"product_id" binding:"required"`
+	PointsRequired int        `json:"points_required" binding:"required"`
+	StartsAt       time.Time  `json:"starts_at" binding:"required"`
+	EndsAt         *time.Time `json:"ends_at"` // Omit for a permanent change
+	Note           string     `json:"note"`
+}
+
+// CreateScheduledChange schedules a product price change
+// POST /api/v1/admin/price-schedules
+func (h *AdminPriceScheduleHandler) CreateScheduledChange(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	var req ScheduledPriceChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	change, err := h.priceScheduleService.CreateScheduledChange(&service.ScheduledPriceChangeRequest{
+		ProductID:      req.ProductID,
+		PointsRequired: req.PointsRequired,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		Note:           req.Note,
+	}, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"price_change": change,
+	})
+}
+
+// ListScheduledChanges lists scheduled price changes with optional status and product filters
+// GET /api/v1/admin/price-schedules?status=pending&product_id=1
+func (h *AdminPriceScheduleHandler) ListScheduledChanges(c *gin.Context) {
+	var status *string
+	if statusStr := c.Query("status"); statusStr != "" {
+		status = &statusStr
+	}
+
+	var productID *uint
+	if productIDStr := c.Query("product_id"); productIDStr != "" {
+		id, err := strconv.ParseUint(productIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid product ID",
+			})
+			return
+		}
+		productUID := uint(id)
+		productID = &productUID
+	}
+
+	changes, err := h.priceScheduleService.ListScheduledChanges(status, productID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve scheduled price changes",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"price_changes": changes,
+	})
+}
+
+// CancelScheduledChange cancels a pending price change
+// POST /api/v1/admin/price-schedules/:id/cancel
+func (h *AdminPriceScheduleHandler) CancelScheduledChange(c *gin.Context) {
+	changeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid scheduled price change ID",
+		})
+		return
+	}
+
+	change, err := h.priceScheduleService.CancelScheduledChange(uint(changeID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"price_change": change,
+	})
+}
+
+// RegisterRoutes registers admin scheduled price change routes
+func (h *AdminPriceScheduleHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/price-schedules")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.POST("", h.CreateScheduledChange)
+		admin.GET("", h.ListScheduledChanges)
+		admin.POST("/:id/cancel", h.CancelScheduledChange)
+	}
+}
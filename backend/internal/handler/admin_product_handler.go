@@ -2,9 +2,11 @@ package handler
 
 import (
 	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/models"
 	"awsome-shop/internal/service"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,10 +25,22 @@ func NewAdminProductHandler(productService *service.ProductService) *AdminProduc
 
 // CreateProductRequest represents a request to create a product
 type CreateProductRequest struct {
-	Name           string `json:"name" binding:"required"`
-	ImageURL       string `json:"image_url"`
-	PointsRequired int    `json:"points_required" binding:"required,min=1"`
-	StockQuantity  int    `json:"stock_quantity" binding:"min=0"`
+	Name               string     `json:"name"` // Deprecated; use name_zh and name_en
+	NameZh             string     `json:"name_zh"`
+	NameEn             string     `json:"name_en"`
+	ShortDescriptionZh string     `json:"short_description_zh"`
+	ShortDescriptionEn string     `json:"short_description_en"`
+	DescriptionZh      string     `json:"description_zh"`
+	DescriptionEn      string     `json:"description_en"`
+	ImageURL           string     `json:"image_url"`
+	PointsRequired     int        `json:"points_required" binding:"required,min=1"`
+	StockQuantity      int        `json:"stock_quantity" binding:"min=0"`
+	LowStockThreshold  int        `json:"low_stock_threshold" binding:"min=0"`
+	AcceptedWallets    []string   `json:"accepted_wallets"`
+	CategoryID         *uint      `json:"category_id"`
+	Tags               []string   `json:"tags"`
+	AvailableFrom      *time.Time `json:"available_from"`
+	AvailableUntil     *time.Time `json:"available_until"`
 }
 
 // CreateProduct creates a new product
@@ -49,10 +63,22 @@ func (h *AdminProductHandler) CreateProduct(c *gin.Context) {
 	}
 
 	createReq := &service.CreateProductRequest{
-		Name:           req.Name,
-		ImageURL:       req.ImageURL,
-		PointsRequired: req.PointsRequired,
-		StockQuantity:  req.StockQuantity,
+		Name:               req.Name,
+		NameZh:             req.NameZh,
+		NameEn:             req.NameEn,
+		ShortDescriptionZh: req.ShortDescriptionZh,
+		ShortDescriptionEn: req.ShortDescriptionEn,
+		DescriptionZh:      req.DescriptionZh,
+		DescriptionEn:      req.DescriptionEn,
+		ImageURL:           req.ImageURL,
+		PointsRequired:     req.PointsRequired,
+		StockQuantity:      req.StockQuantity,
+		LowStockThreshold:  req.LowStockThreshold,
+		AcceptedWallets:    req.AcceptedWallets,
+		CategoryID:         req.CategoryID,
+		Tags:               req.Tags,
+		AvailableFrom:      req.AvailableFrom,
+		AvailableUntil:     req.AvailableUntil,
 	}
 
 	product, err := h.productService.CreateProduct(createReq, operatorID)
@@ -70,10 +96,20 @@ func (h *AdminProductHandler) CreateProduct(c *gin.Context) {
 
 // UpdateProductRequest represents a request to update a product
 type UpdateProductRequest struct {
-	Name           *string `json:"name"`
-	ImageURL       *string `json:"image_url"`
-	PointsRequired *int    `json:"points_required"`
-	StockQuantity  *int    `json:"stock_quantity"`
+	Name               *string  `json:"name"` // Deprecated; use name_zh and name_en
+	NameZh             *string  `json:"name_zh"`
+	NameEn             *string  `json:"name_en"`
+	ShortDescriptionZh *string  `json:"short_description_zh"`
+	ShortDescriptionEn *string  `json:"short_description_en"`
+	DescriptionZh      *string  `json:"description_zh"`
+	DescriptionEn      *string  `json:"description_en"`
+	ImageURL           *string  `json:"image_url"`
+	PointsRequired     *int     `json:"points_required"`
+	StockQuantity      *int     `json:"stock_quantity"`
+	LowStockThreshold  *int     `json:"low_stock_threshold"`
+	AcceptedWallets    []string `json:"accepted_wallets"`
+	CategoryID         *uint    `json:"category_id"` // 0 clears the category
+	Tags               []string `json:"tags"`        // Empty clears the tags
 }
 
 // UpdateProduct updates a product
@@ -106,10 +142,20 @@ func (h *AdminProductHandler) UpdateProduct(c *gin.Context) {
 	}
 
 	updateReq := &service.UpdateProductRequest{
-		Name:           req.Name,
-		ImageURL:       req.ImageURL,
-		PointsRequired: req.PointsRequired,
-		StockQuantity:  req.StockQuantity,
+		Name:               req.Name,
+		NameZh:             req.NameZh,
+		NameEn:             req.NameEn,
+		ShortDescriptionZh: req.ShortDescriptionZh,
+		ShortDescriptionEn: req.ShortDescriptionEn,
+		DescriptionZh:      req.DescriptionZh,
+		DescriptionEn:      req.DescriptionEn,
+		ImageURL:           req.ImageURL,
+		PointsRequired:     req.PointsRequired,
+		StockQuantity:      req.StockQuantity,
+		LowStockThreshold:  req.LowStockThreshold,
+		AcceptedWallets:    req.AcceptedWallets,
+		CategoryID:         req.CategoryID,
+		Tags:               req.Tags,
 	}
 
 	product, err := h.productService.UpdateProduct(uint(productID), updateReq, operatorID)
@@ -164,9 +210,50 @@ func (h *AdminProductHandler) SetProductStatus(c *gin.Context) {
 	})
 }
 
+// SetAvailabilityRequest represents a request to set a product's availability window
+// Both bounds are replaced; null or an omitted bound removes it.
+type SetAvailabilityRequest struct {
+	AvailableFrom  *time.Time `json:"available_from"`
+	AvailableUntil *time.Time `json:"available_until"`
+}
+
+// SetAvailability sets the window in which a product can be seen and redeemed
+// PUT /api/v1/admin/products/:id/availability
+func (h *AdminProductHandler) SetAvailability(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var req SetAvailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	product, err := h.productService.SetAvailability(uint(productID), req.AvailableFrom, req.AvailableUntil)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"product": product,
+	})
+}
+
 // BatchImportProductsRequest represents a request to batch import products
 type BatchImportProductsRequest struct {
 	Markdown string `json:"markdown" binding:"required"`
+	DryRun   bool   `json:"dry_run"`
+	Partial  bool   `json:"partial"`
 }
 
 // BatchImportProducts imports multiple products from markdown table
@@ -188,18 +275,31 @@ func (h *AdminProductHandler) BatchImportProducts(c *gin.Context) {
 		return
 	}
 
-	products, err := h.productService.BatchImportProducts(req.Markdown, operatorID)
+	opts := service.BatchOptions{DryRun: req.DryRun, Partial: req.Partial}
+	result, err := h.productService.BatchImportProducts(req.Markdown, operatorID, opts)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
+		respondBatchError(c, err)
+		return
+	}
+
+	if result.DryRun {
+		c.JSON(http.StatusOK, gin.H{
+			"message": "Dry run completed, no products were created",
+			"result":  result,
 		})
 		return
 	}
 
+	message := "Products imported successfully"
+	if result.Failed > 0 {
+		message = "Valid products imported, some rows failed"
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
-		"products": products,
-		"count":    len(products),
-		"message":  "Products imported successfully",
+		"products": result.Products,
+		"count":    len(result.Products),
+		"message":  message,
+		"result":   result,
 	})
 }
 
@@ -225,6 +325,223 @@ func (h *AdminProductHandler) ListAllProducts(c *gin.Context) {
 	})
 }
 
+// ListLowStockProducts lists active products at or below their low-stock threshold
+// GET /api/v1/admin/products/low-stock
+func (h *AdminProductHandler) ListLowStockProducts(c *gin.Context) {
+	products, err := h.productService.ListLowStockProducts()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve low-stock products",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"products": products,
+	})
+}
+
+// parseVariantParams reads the product and variant IDs from the URL
+func parseVariantParams(c *gin.Context) (uint, uint, bool) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return 0, 0, false
+	}
+
+	variantID, err := strconv.ParseUint(c.Param("variant_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid variant ID",
+		})
+		return 0, 0, false
+	}
+
+	return uint(productID), uint(variantID), true
+}
+
+// ProductVariantRequest represents a request to create or update a product variant
+type ProductVariantRequest struct {
+	Attributes     []models.VariantAttribute `json:"attributes" binding:"required,min=1"`
+	PointsOverride *int                      `json:"points_override"`
+	StockQuantity  int                       `json:"stock_quantity" binding:"min=0"`
+	SortOrder      int                       `json:"sort_order"`
+}
+
+// toServiceRequest converts the handler request to a service request
+func (r *ProductVariantRequest) toServiceRequest() *service.ProductVariantRequest {
+	return &service.ProductVariantRequest{
+		Attributes:     r.Attributes,
+		PointsOverride: r.PointsOverride,
+		StockQuantity:  r.StockQuantity,
+		SortOrder:      r.SortOrder,
+	}
+}
+
+// CreateVariant adds a variant to a product
+// POST /api/v1/admin/products/:id/variants
+func (h *AdminProductHandler) CreateVariant(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var req ProductVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	variant, err := h.productService.CreateVariant(uint(productID), req.toServiceRequest(), operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"variant": variant,
+	})
+}
+
+// ListVariants lists a product's variants, including inactive ones
+// GET /api/v1/admin/products/:id/variants
+func (h *AdminProductHandler) ListVariants(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	variants, err := h.productService.ListVariants(uint(productID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"variants": variants,
+	})
+}
+
+// UpdateVariant updates a product variant
+// PUT /api/v1/admin/products/:id/variants/:variant_id
+func (h *AdminProductHandler) UpdateVariant(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	productID, variantID, ok := parseVariantParams(c)
+	if !ok {
+		return
+	}
+
+	var req ProductVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	variant, err := h.productService.UpdateVariant(productID, variantID, req.toServiceRequest(), operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"variant": variant,
+	})
+}
+
+// SetVariantStatus sets a product variant's status (active/inactive)
+// PUT /api/v1/admin/products/:id/variants/:variant_id/status
+func (h *AdminProductHandler) SetVariantStatus(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	productID, variantID, ok := parseVariantParams(c)
+	if !ok {
+		return
+	}
+
+	var req SetProductStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format. Status must be 'active' or 'inactive'",
+		})
+		return
+	}
+
+	err := h.productService.SetVariantStatus(productID, variantID, req.Status, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Variant status updated successfully",
+	})
+}
+
+// GetPriceHistory lists a product's price changes with the admins who made them, newest first
+// GET /api/v1/admin/products/:id/price-history
+func (h *AdminProductHandler) GetPriceHistory(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	history, err := h.productService.GetPriceHistory(uint(productID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"price_history": history,
+	})
+}
+
 // RegisterRoutes registers admin product routes
 func (h *AdminProductHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
 	admin := router.Group("/admin/products")
@@ -233,7 +550,14 @@ func (h *AdminProductHandler) RegisterRoutes(router *gin.RouterGroup, authMiddle
 		admin.POST("", h.CreateProduct)
 		admin.PUT("/:id", h.UpdateProduct)
 		admin.PUT("/:id/status", h.SetProductStatus)
+		admin.PUT("/:id/availability", h.SetAvailability)
 		admin.POST("/batch", h.BatchImportProducts)
 		admin.GET("", h.ListAllProducts)
+		admin.GET("/low-stock", h.ListLowStockProducts)
+		admin.POST("/:id/variants", h.CreateVariant)
+		admin.GET("/:id/variants", h.ListVariants)
+		admin.PUT("/:id/variants/:variant_id", h.UpdateVariant)
+		admin.PUT("/:id/variants/:variant_id/status", h.SetVariantStatus)
+		admin.GET("/:id/price-history", h.GetPriceHistory)
 	}
 }
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"awsome-shop/internal/service"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,20 +13,29 @@ import (
 type AdminReportHandler struct {
 	pointsService     *service.PointsService
 	redemptionService *service.RedemptionService
+	campaignService   *service.CampaignService
+	anomalyService    *service.AnomalyService
+	productService    *service.ProductService
 }
 
 // NewAdminReportHandler creates a new AdminReportHandler instance
-func NewAdminReportHandler(pointsService *service.PointsService, redemptionService *service.RedemptionService) *AdminReportHandler {
+func NewAdminReportHandler(pointsService *service.PointsService, redemptionService *service.RedemptionService, campaignService *service.CampaignService, anomalyService *service.AnomalyService, productService *service.ProductService) *AdminReportHandler {
 	return &AdminReportHandler{
 		pointsService:     pointsService,
 		redemptionService: redemptionService,
+		campaignService:   campaignService,
+		anomalyService:    anomalyService,
+		productService:    productService,
 	}
 }
 
 // GetPointsGrantsReport gets the points grants report
-// GET /api/v1/admin/reports/points-grants
+// Grants are listed individually and totalled per reason code.
+// GET /api/v1/admin/reports/points-grants?net_reversals=true
 func (h *AdminReportHandler) GetPointsGrantsReport(c *gin.Context) {
-	stats, err := h.pointsService.GetGrantTransactionsReport()
+	netReversals := c.Query("net_reversals") == "true"
+
+	report, err := h.pointsService.GetGrantTransactionsReport(netReversals)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Failed to retrieve points grants report",
@@ -33,7 +44,8 @@ func (h *AdminReportHandler) GetPointsGrantsReport(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"grants": stats,
+		"grants":         report.Grants,
+		"by_reason_code": report.ByReasonCode,
 	})
 }
 
@@ -69,6 +81,97 @@ func (h *AdminReportHandler) GetRedemptionsReport(c *gin.Context) {
 	})
 }
 
+// GetCampaignsReport gets redemption counts, points spent and bonus points per campaign
+// GET /api/v1/admin/reports/campaigns
+func (h *AdminReportHandler) GetCampaignsReport(c *gin.Context) {
+	stats, err := h.campaignService.GetEffectivenessReport()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve campaigns report",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"campaigns": stats,
+	})
+}
+
+// parseReportRange parses optional from/to query parameters; a date-only "to" includes that whole day
+func parseReportRange(c *gin.Context) (*time.Time, *time.Time, error) {
+	var from, to *time.Time
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		t, _, err := parseQueryTime(fromStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid from date: %s", fromStr)
+		}
+		from = &t
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		t, dateOnly, err := parseQueryTime(toStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid to date: %s", toStr)
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		to = &t
+	}
+
+	return from, to, nil
+}
+
+// GetAnomaliesReport flags large grants shortly before redemptions, grants outside working
+// hours and repeated round-number grants
+// GET /api/v1/admin/reports/anomalies?from=&to=
+func (h *AdminReportHandler) GetAnomaliesReport(c *gin.Context) {
+	from, to, err := parseReportRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	report, err := h.anomalyService.GetAnomalyReport(from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
+// GetPriceChangesReport lists product price changes across the catalog, defaulting to the last 30 days
+// GET /api/v1/admin/reports/price-changes?from=&to=
+func (h *AdminReportHandler) GetPriceChangesReport(c *gin.Context) {
+	from, to, err := parseReportRange(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	report, err := h.productService.GetPriceChangeReport(from, to)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"report": report,
+	})
+}
+
 // RegisterRoutes registers admin report routes
 func (h *AdminReportHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
 	admin := router.Group("/admin/reports")
@@ -77,5 +180,8 @@ func (h *AdminReportHandler) RegisterRoutes(router *gin.RouterGroup, authMiddlew
 		admin.GET("/points-grants", h.GetPointsGrantsReport)
 		admin.GET("/points-balances", h.GetPointsBalancesReport)
 		admin.GET("/redemptions", h.GetRedemptionsReport)
+		admin.GET("/campaigns", h.GetCampaignsReport)
+		admin.GET("/anomalies", h.GetAnomaliesReport)
+		admin.GET("/price-changes", h.GetPriceChangesReport)
 	}
 }
@@ -0,0 +1,408 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSegmentHandler handles admin user segment and segment grant requests
+type AdminSegmentHandler struct {
+	segmentService *service.SegmentService
+}
+
+// NewAdminSegmentHandler creates a new AdminSegmentHandler instance
+func NewAdminSegmentHandler(segmentService *service.SegmentService) *AdminSegmentHandler {
+	return &AdminSegmentHandler{
+		segmentService: segmentService,
+	}
+}
+
+// SegmentRequest represents a request to create or update a segment
+type SegmentRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	UserStatus  string `json:"user_status" binding:"omitempty,oneof=any active inactive"` // Defaults to active
+	Department  string `json:"department"`
+	Location    string `json:"location"`
+	Role        string `json:"role" binding:"omitempty,oneof=employee admin"`
+	JoinedFrom  string `json:"joined_from"` // YYYY-MM-DD, optional
+	JoinedTo    string `json:"joined_to"`   // YYYY-MM-DD, optional
+}
+
+// toServiceRequest converts the handler request to a service request
+func (r *SegmentRequest) toServiceRequest() (*service.SegmentRequest, error) {
+	segmentReq := &service.SegmentRequest{
+		Name:        r.Name,
+		Description: r.Description,
+		UserStatus:  r.UserStatus,
+		Department:  r.Department,
+		Location:    r.Location,
+		Role:        r.Role,
+	}
+
+	if r.JoinedFrom != "" {
+		joinedFrom, err := time.ParseInLocation("2006-01-02", r.JoinedFrom, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid joined_from date. Expected format: YYYY-MM-DD")
+		}
+		segmentReq.JoinedFrom = &joinedFrom
+	}
+
+	if r.JoinedTo != "" {
+		joinedTo, err := time.ParseInLocation("2006-01-02", r.JoinedTo, time.Local)
+		if err != nil {
+			return nil, errors.New("invalid joined_to date. Expected format: YYYY-MM-DD")
+		}
+		segmentReq.JoinedTo = &joinedTo
+	}
+
+	return segmentReq, nil
+}
+
+// parseSegmentID parses the segment ID from the URL
+func parseSegmentID(c *gin.Context) (uint, bool) {
+	segmentID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid segment ID",
+		})
+		return 0, false
+	}
+	return uint(segmentID), true
+}
+
+// CreateSegment saves a new segment
+// POST /api/v1/admin/segments
+func (h *AdminSegmentHandler) CreateSegment(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	var req SegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	segmentReq, err := req.toServiceRequest()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	segment, err := h.segmentService.CreateSegment(segmentReq, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"segment": segment,
+	})
+}
+
+// ListSegments lists saved segments
+// GET /api/v1/admin/segments
+func (h *AdminSegmentHandler) ListSegments(c *gin.Context) {
+	segments, err := h.segmentService.ListSegments()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve segments",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"segments": segments,
+	})
+}
+
+// GetSegment gets a segment by ID
+// GET /api/v1/admin/segments/:id
+func (h *AdminSegmentHandler) GetSegment(c *gin.Context) {
+	segmentID, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	segment, err := h.segmentService.GetSegmentByID(segmentID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"segment": segment,
+	})
+}
+
+// UpdateSegment replaces a segment's name and filters
+// PUT /api/v1/admin/segments/:id
+func (h *AdminSegmentHandler) UpdateSegment(c *gin.Context) {
+	segmentID, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	var req SegmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	segmentReq, err := req.toServiceRequest()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	segment, err := h.segmentService.UpdateSegment(segmentID, segmentReq)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"segment": segment,
+	})
+}
+
+// DeleteSegment deletes a segment that has never been used for a grant
+// DELETE /api/v1/admin/segments/:id
+func (h *AdminSegmentHandler) DeleteSegment(c *gin.Context) {
+	segmentID, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	err := h.segmentService.DeleteSegment(segmentID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Segment deleted successfully",
+	})
+}
+
+// PreviewSegment lists the users a grant to the segment would reach and its total cost
+// GET /api/v1/admin/segments/:id/preview?amount=200
+func (h *AdminSegmentHandler) PreviewSegment(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	segmentID, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	amount := 0
+	if amountStr := c.Query("amount"); amountStr != "" {
+		var err error
+		amount, err = strconv.Atoi(amountStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid amount",
+			})
+			return
+		}
+	}
+
+	preview, err := h.segmentService.PreviewSegment(segmentID, amount, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preview": preview,
+	})
+}
+
+// SegmentGrantRequest represents a request to grant points to every user in a segment
+type SegmentGrantRequest struct {
+	Wallet     string `json:"wallet" binding:"omitempty,oneof=benefit recognition"` // Defaults to benefit
+	Amount     int    `json:"amount" binding:"required,min=1"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"` // Required without a reason code, or when the code requires a note
+}
+
+// GrantToSegment starts a background job granting points to every eligible user in the segment
+// POST /api/v1/admin/segments/:id/grant
+func (h *AdminSegmentHandler) GrantToSegment(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	segmentID, ok := parseSegmentID(c)
+	if !ok {
+		return
+	}
+
+	var req SegmentGrantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	grantReq := &service.SegmentGrantRequest{
+		Wallet:     req.Wallet,
+		Amount:     req.Amount,
+		ReasonCode: req.ReasonCode,
+		Reason:     req.Reason,
+	}
+
+	job, err := h.segmentService.StartGrantJob(segmentID, grantReq, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Segment grant started",
+		"job":     job,
+	})
+}
+
+// ListGrantJobs lists segment grant jobs
+// GET /api/v1/admin/segment-grants?segment_id=
+func (h *AdminSegmentHandler) ListGrantJobs(c *gin.Context) {
+	var segmentID *uint
+	if segmentIDStr := c.Query("segment_id"); segmentIDStr != "" {
+		id, err := strconv.ParseUint(segmentIDStr, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid segment ID",
+			})
+			return
+		}
+		sid := uint(id)
+		segmentID = &sid
+	}
+
+	jobs, err := h.segmentService.ListGrantJobs(segmentID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve segment grants",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs": jobs,
+	})
+}
+
+// GetGrantJob gets a segment grant job and its progress
+// GET /api/v1/admin/segment-grants/:id
+func (h *AdminSegmentHandler) GetGrantJob(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID",
+		})
+		return
+	}
+
+	job, err := h.segmentService.GetGrantJob(uint(jobID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": job,
+	})
+}
+
+// ResumeGrantJob resumes a failed or stalled segment grant job
+// POST /api/v1/admin/segment-grants/:id/resume
+func (h *AdminSegmentHandler) ResumeGrantJob(c *gin.Context) {
+	jobID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid job ID",
+		})
+		return
+	}
+
+	job, err := h.segmentService.ResumeGrantJob(uint(jobID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "Segment grant resumed",
+		"job":     job,
+	})
+}
+
+// RegisterRoutes registers admin segment routes
+func (h *AdminSegmentHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	segments := router.Group("/admin/segments")
+	segments.Use(authMiddleware, adminMiddleware)
+	{
+		segments.POST("", h.CreateSegment)
+		segments.GET("", h.ListSegments)
+		segments.GET("/:id", h.GetSegment)
+		segments.PUT("/:id", h.UpdateSegment)
+		segments.DELETE("/:id", h.DeleteSegment)
+		segments.GET("/:id/preview", h.PreviewSegment)
+		segments.POST("/:id/grant", h.GrantToSegment)
+	}
+
+	grants := router.Group("/admin/segment-grants")
+	grants.Use(authMiddleware, adminMiddleware)
+	{
+		grants.GET("", h.ListGrantJobs)
+		grants.GET("/:id", h.GetGrantJob)
+		grants.POST("/:id/resume", h.ResumeGrantJob)
+	}
+}
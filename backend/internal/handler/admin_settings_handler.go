@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminSettingsHandler handles admin settings requests
+type AdminSettingsHandler struct {
+	welcomeBonusService *service.WelcomeBonusService
+}
+
+// NewAdminSettingsHandler creates a new AdminSettingsHandler instance
+func NewAdminSettingsHandler(welcomeBonusService *service.WelcomeBonusService) *AdminSettingsHandler {
+	return &AdminSettingsHandler{
+		welcomeBonusService: welcomeBonusService,
+	}
+}
+
+// GetWelcomeBonusPolicy gets the current welcome bonus policy
+// GET /api/v1/admin/settings/welcome-bonus
+func (h *AdminSettingsHandler) GetWelcomeBonusPolicy(c *gin.Context) {
+	policy, err := h.welcomeBonusService.GetPolicy()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve welcome bonus policy",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policy": policy,
+	})
+}
+
+// UpdateWelcomeBonusPolicyRequest represents a request to update the welcome bonus policy
+type UpdateWelcomeBonusPolicyRequest struct {
+	Enabled            bool                              `json:"enabled"`
+	GrantAt            string                            `json:"grant_at" binding:"required,oneof=creation first_login"`
+	DefaultAmount      int                               `json:"default_amount" binding:"min=0"`
+	ProRateByJoinMonth bool                              `json:"pro_rate_by_join_month"`
+	Rules              []service.WelcomeBonusRuleRequest `json:"rules"`
+}
+
+// UpdateWelcomeBonusPolicy replaces the welcome bonus policy; changes apply to the next grant
+// PUT /api/v1/admin/settings/welcome-bonus
+func (h *AdminSettingsHandler) UpdateWelcomeBonusPolicy(c *gin.Context) {
+	operatorID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Operator ID not found in context",
+		})
+		return
+	}
+
+	var req UpdateWelcomeBonusPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	updateReq := &service.UpdateWelcomeBonusPolicyRequest{
+		Enabled:            req.Enabled,
+		GrantAt:            req.GrantAt,
+		DefaultAmount:      req.DefaultAmount,
+		ProRateByJoinMonth: req.ProRateByJoinMonth,
+		Rules:              req.Rules,
+	}
+
+	policy, err := h.welcomeBonusService.UpdatePolicy(updateReq, operatorID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policy":  policy,
+		"message": "Welcome bonus policy updated successfully",
+	})
+}
+
+// RegisterRoutes registers admin settings routes
+func (h *AdminSettingsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	admin := router.Group("/admin/settings")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.GET("/welcome-bonus", h.GetWelcomeBonusPolicy)
+		admin.PUT("/welcome-bonus", h.UpdateWelcomeBonusPolicy)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"awsome-shop/internal/service"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,9 +24,12 @@ func NewAdminUserHandler(userService *service.UserService) *AdminUserHandler {
 
 // CreateEmployeeRequest represents a request to create an employee
 type CreateEmployeeRequest struct {
-	FullName string `json:"full_name" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	Phone    string `json:"phone" binding:"required"`
+	FullName   string `json:"full_name" binding:"required"`
+	Email      string `json:"email" binding:"required,email"`
+	Phone      string `json:"phone" binding:"required"`
+	Department string `json:"department"`
+	Location   string `json:"location"`
+	JoinDate   string `json:"join_date"` // YYYY-MM-DD, optional
 }
 
 // CreateEmployee creates a new employee account
@@ -40,9 +44,22 @@ func (h *AdminUserHandler) CreateEmployee(c *gin.Context) {
 	}
 
 	createReq := &service.CreateEmployeeRequest{
-		FullName: req.FullName,
-		Email:    req.Email,
-		Phone:    req.Phone,
+		FullName:   req.FullName,
+		Email:      req.Email,
+		Phone:      req.Phone,
+		Department: req.Department,
+		Location:   req.Location,
+	}
+
+	if req.JoinDate != "" {
+		joinDate, err := time.ParseInLocation("2006-01-02", req.JoinDate, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Invalid join date. Expected format: YYYY-MM-DD",
+			})
+			return
+		}
+		createReq.JoinDate = &joinDate
 	}
 
 	user, err := h.userService.CreateEmployee(createReq)
@@ -116,6 +133,43 @@ func (h *AdminUserHandler) SetEmployeeStatus(c *gin.Context) {
 	})
 }
 
+// SetEmployeeManagerRequest represents a request to set an employee's manager
+type SetEmployeeManagerRequest struct {
+	ManagerID *uint `json:"manager_id"` // Null clears the manager
+}
+
+// SetEmployeeManager sets or clears an employee's manager
+// PUT /api/v1/admin/users/:id/manager
+func (h *AdminUserHandler) SetEmployeeManager(c *gin.Context) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid user ID",
+		})
+		return
+	}
+
+	var req SetEmployeeManagerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	err = h.userService.SetManager(uint(userID), req.ManagerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Employee manager updated successfully",
+	})
+}
+
 // ListEmployees lists all employees
 // GET /api/v1/admin/users
 func (h *AdminUserHandler) ListEmployees(c *gin.Context) {
@@ -151,6 +205,7 @@ func (h *AdminUserHandler) RegisterRoutes(router *gin.RouterGroup, authMiddlewar
 	{
 		admin.POST("", h.CreateEmployee)
 		admin.PUT("/:id/status", h.SetEmployeeStatus)
+		admin.PUT("/:id/manager", h.SetEmployeeManager)
 		admin.GET("", h.ListEmployees)
 	}
 }
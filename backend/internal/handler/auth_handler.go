@@ -1,7 +1,6 @@
 package handler
 
 import (
-	"awsome-shop/internal/middleware"
 	"awsome-shop/internal/service"
 	"net/http"
 
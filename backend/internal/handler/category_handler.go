@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"awsome-shop/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler handles product category and tag requests
+type CategoryHandler struct {
+	categoryService *service.CategoryService
+}
+
+// NewCategoryHandler creates a new CategoryHandler instance
+func NewCategoryHandler(categoryService *service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+// parseIDParam reads the :id path parameter, writing a 400 response naming what when it is invalid
+func parseIDParam(c *gin.Context, what string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid " + what + " ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// CategoryRequest represents a request to create or update a category
+type CategoryRequest struct {
+	ParentID  *uint  `json:"parent_id"`
+	Name      string `json:"name" binding:"required"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// toServiceRequest converts the handler request to a service request
+func (r *CategoryRequest) toServiceRequest() *service.CategoryRequest {
+	return &service.CategoryRequest{
+		ParentID:  r.ParentID,
+		Name:      r.Name,
+		SortOrder: r.SortOrder,
+	}
+}
+
+// GetCategoryTree gets all categories nested under their parents
+// GET /api/v1/categories
+func (h *CategoryHandler) GetCategoryTree(c *gin.Context) {
+	categories, err := h.categoryService.GetCategoryTree()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve categories",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"categories": categories,
+	})
+}
+
+// CreateCategory creates a category
+// POST /api/v1/admin/categories
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(req.toServiceRequest())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"category": category,
+	})
+}
+
+// UpdateCategory renames, reorders or moves a category
+// PUT /api/v1/admin/categories/:id
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category")
+	if !ok {
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(categoryID, req.toServiceRequest())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"category": category,
+	})
+}
+
+// DeleteCategory deletes an empty category
+// DELETE /api/v1/admin/categories/:id
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	categoryID, ok := parseIDParam(c, "category")
+	if !ok {
+		return
+	}
+
+	err := h.categoryService.DeleteCategory(categoryID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Category deleted successfully",
+	})
+}
+
+// TagRequest represents a request to create or rename a tag
+type TagRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// ListTags lists all tags with their product counts
+// GET /api/v1/tags
+func (h *CategoryHandler) ListTags(c *gin.Context) {
+	tags, err := h.categoryService.ListTags()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve tags",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tags": tags,
+	})
+}
+
+// CreateTag creates a tag
+// POST /api/v1/admin/tags
+func (h *CategoryHandler) CreateTag(c *gin.Context) {
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	tag, err := h.categoryService.CreateTag(req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"tag": tag,
+	})
+}
+
+// RenameTag renames a tag
+// PUT /api/v1/admin/tags/:id
+func (h *CategoryHandler) RenameTag(c *gin.Context) {
+	tagID, ok := parseIDParam(c, "tag")
+	if !ok {
+		return
+	}
+
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	tag, err := h.categoryService.RenameTag(tagID, req.Name)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tag": tag,
+	})
+}
+
+// DeleteTag deletes a tag and removes it from every product
+// DELETE /api/v1/admin/tags/:id
+func (h *CategoryHandler) DeleteTag(c *gin.Context) {
+	tagID, ok := parseIDParam(c, "tag")
+	if !ok {
+		return
+	}
+
+	err := h.categoryService.DeleteTag(tagID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Tag deleted successfully",
+	})
+}
+
+// RegisterRoutes registers category and tag routes
+func (h *CategoryHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	catalog := router.Group("")
+	catalog.Use(authMiddleware)
+	{
+		catalog.GET("/categories", h.GetCategoryTree)
+		catalog.GET("/tags", h.ListTags)
+	}
+
+	categories := router.Group("/admin/categories")
+	categories.Use(authMiddleware, adminMiddleware)
+	{
+		categories.POST("", h.CreateCategory)
+		categories.GET("", h.GetCategoryTree)
+		categories.PUT("/:id", h.UpdateCategory)
+		categories.DELETE("/:id", h.DeleteCategory)
+	}
+
+	tags := router.Group("/admin/tags")
+	tags.Use(authMiddleware, adminMiddleware)
+	{
+		tags.POST("", h.CreateTag)
+		tags.GET("", h.ListTags)
+		tags.PUT("/:id", h.RenameTag)
+		tags.DELETE("/:id", h.DeleteTag)
+	}
+}
@@ -2,34 +2,76 @@ package handler
 
 import (
 	"awsome-shop/internal/service"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
 )
 
 // Handlers holds all handler instances
 type Handlers struct {
-	Auth              *AuthHandler
-	User              *UserHandler
-	Product           *ProductHandler
-	Redemption        *RedemptionHandler
-	Points            *PointsHandler
-	AdminUser         *AdminUserHandler
-	AdminProduct      *AdminProductHandler
-	AdminPoints       *AdminPointsHandler
-	AdminOrder        *AdminOrderHandler
-	AdminReport       *AdminReportHandler
+	Auth           *AuthHandler
+	User           *UserHandler
+	Product        *ProductHandler
+	Redemption     *RedemptionHandler
+	Points         *PointsHandler
+	AdminUser      *AdminUserHandler
+	AdminProduct   *AdminProductHandler
+	AdminPoints    *AdminPointsHandler
+	AdminOrder     *AdminOrderHandler
+	AdminReport    *AdminReportHandler
+	AdminSettings  *AdminSettingsHandler
+	AdminCampaign  *AdminCampaignHandler
+	Manager        *ManagerHandler
+	AdminAllowance *AdminManagerAllowanceHandler
+	AdminSegment   *AdminSegmentHandler
+	ReasonCode     *ReasonCodeHandler
+	Category       *CategoryHandler
+	ProductImage   *ProductImageHandler
+	PriceSchedule  *AdminPriceScheduleHandler
+	AdminInventory *AdminInventoryHandler
+	Notification   *NotificationHandler
 }
 
 // NewHandlers creates and initializes all handlers
 func NewHandlers(services *service.Services) *Handlers {
 	return &Handlers{
-		Auth:         NewAuthHandler(services.Auth),
-		User:         NewUserHandler(services.User),
-		Product:      NewProductHandler(services.Product),
-		Redemption:   NewRedemptionHandler(services.Redemption),
-		Points:       NewPointsHandler(services.Points),
-		AdminUser:    NewAdminUserHandler(services.User),
-		AdminProduct: NewAdminProductHandler(services.Product),
-		AdminPoints:  NewAdminPointsHandler(services.Points),
-		AdminOrder:   NewAdminOrderHandler(services.Redemption),
-		AdminReport:  NewAdminReportHandler(services.Points, services.Redemption),
+		Auth:           NewAuthHandler(services.Auth),
+		User:           NewUserHandler(services.User),
+		Product:        NewProductHandler(services.Product),
+		Redemption:     NewRedemptionHandler(services.Redemption),
+		Points:         NewPointsHandler(services.Points, services.Statement),
+		AdminUser:      NewAdminUserHandler(services.User),
+		AdminProduct:   NewAdminProductHandler(services.Product),
+		AdminPoints:    NewAdminPointsHandler(services.Points, services.Reconciliation, services.Ledger, services.Statement),
+		AdminOrder:     NewAdminOrderHandler(services.Redemption),
+		AdminReport:    NewAdminReportHandler(services.Points, services.Redemption, services.Campaign, services.Anomaly, services.Product),
+		AdminSettings:  NewAdminSettingsHandler(services.WelcomeBonus),
+		AdminCampaign:  NewAdminCampaignHandler(services.Campaign),
+		Manager:        NewManagerHandler(services.ManagerAllowance),
+		AdminAllowance: NewAdminManagerAllowanceHandler(services.ManagerAllowance),
+		AdminSegment:   NewAdminSegmentHandler(services.Segment),
+		ReasonCode:     NewReasonCodeHandler(services.ReasonCode),
+		Category:       NewCategoryHandler(services.Category),
+		ProductImage:   NewProductImageHandler(services.ProductImage),
+		PriceSchedule:  NewAdminPriceScheduleHandler(services.PriceSchedule),
+		AdminInventory: NewAdminInventoryHandler(services.Inventory),
+		Notification:   NewNotificationHandler(services.Notification),
 	}
 }
+
+// respondBatchError writes a failed batch operation, including per-row details when available
+func respondBatchError(c *gin.Context, err error) {
+	var validationErr *service.BatchValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":      err.Error(),
+			"row_errors": validationErr.Rows,
+		})
+		return
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": err.Error(),
+	})
+}
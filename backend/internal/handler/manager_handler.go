@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"awsome-shop/internal/middleware"
+	"awsome-shop/internal/service"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ManagerHandler handles requests from managers acting on their direct reports
+type ManagerHandler struct {
+	allowanceService *service.ManagerAllowanceService
+}
+
+// NewManagerHandler creates a new ManagerHandler instance
+func NewManagerHandler(allowanceService *service.ManagerAllowanceService) *ManagerHandler {
+	return &ManagerHandler{
+		allowanceService: allowanceService,
+	}
+}
+
+// GetDirectReports lists the current user's direct reports
+// GET /api/v1/manager/reports
+func (h *ManagerHandler) GetDirectReports(c *gin.Context) {
+	managerID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
+	reports, err := h.allowanceService.GetDirectReports(managerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve direct reports",
+		})
+		return
+	}
+
+	// Only expose what a manager needs to pick a report
+	type directReport struct {
+		ID         uint   `json:"id"`
+		FullName   string `json:"full_name"`
+		Email      string `json:"email"`
+		Department string `json:"department"`
+		IsActive   bool   `json:"is_active"`
+	}
+	result := make([]directReport, 0, len(reports))
+	for _, report := range reports {
+		result = append(result, directReport{
+			ID:         report.ID,
+			FullName:   report.FullName,
+			Email:      report.Email,
+			Department: report.Department,
+			IsActive:   report.IsActive,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reports": result,
+	})
+}
+
+// GetAllowances lists the current user's manager allowances
+// GET /api/v1/manager/allowances
+func (h *ManagerHandler) GetAllowances(c *gin.Context) {
+	managerID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
+	allowances, err := h.allowanceService.ListAllowances(&managerID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve allowances",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"allowances": allowances,
+	})
+}
+
+// ManagerGrantPointsRequest represents a request from a manager to grant points to a report
+type ManagerGrantPointsRequest struct {
+	UserID     uint   `json:"user_id" binding:"required"`
+	Amount     int    `json:"amount" binding:"required,min=1"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"` // Required without a reason code, or when the code requires a note
+}
+
+// GrantPoints grants points from the current user's allowance to one of their direct reports
+// POST /api/v1/manager/points/grant
+func (h *ManagerHandler) GrantPoints(c *gin.Context) {
+	managerID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
+	var req ManagerGrantPointsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	transaction, err := h.allowanceService.GrantToDirectReport(managerID, req.UserID, req.Amount, req.ReasonCode, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Points granted successfully",
+		"transaction": transaction,
+	})
+}
+
+// RegisterRoutes registers manager routes
+func (h *ManagerHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
+	manager := router.Group("/manager")
+	manager.Use(authMiddleware)
+	{
+		manager.GET("/reports", h.GetDirectReports)
+		manager.GET("/allowances", h.GetAllowances)
+		manager.POST("/points/grant", h.GrantPoints)
+	}
+}
@@ -3,21 +3,26 @@ package handler
 import (
 	"awsome-shop/internal/middleware"
 	"awsome-shop/internal/service"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // PointsHandler handles points related requests
 type PointsHandler struct {
-	pointsService *service.PointsService
+	pointsService    *service.PointsService
+	statementService *service.StatementService
 }
 
 // NewPointsHandler creates a new PointsHandler instance
-func NewPointsHandler(pointsService *service.PointsService) *PointsHandler {
+func NewPointsHandler(pointsService *service.PointsService, statementService *service.StatementService) *PointsHandler {
 	return &PointsHandler{
-		pointsService: pointsService,
+		pointsService:    pointsService,
+		statementService: statementService,
 	}
 }
 
@@ -40,13 +45,23 @@ func (h *PointsHandler) GetPointsBalance(c *gin.Context) {
 		return
 	}
 
+	wallets, err := h.pointsService.GetWalletBalances(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve wallet balances",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"balance": balance,
+		"wallets": wallets,
 	})
 }
 
 // GetPointsTransactions gets the current user's points transaction history
-// GET /api/v1/points/transactions
+// GET /api/v1/points/transactions?type=grant,deduct&wallet=benefit&from=2024-01-01&to=2024-01-31&min_amount=&max_amount=&reason=&cursor=&limit=
+// Requests with a page parameter use the legacy page/page_size pagination without filters
 func (h *PointsHandler) GetPointsTransactions(c *gin.Context) {
 	userID, exists := middleware.GetUserID(c)
 	if !exists {
@@ -56,6 +71,27 @@ func (h *PointsHandler) GetPointsTransactions(c *gin.Context) {
 		return
 	}
 
+	if c.Query("page") == "" {
+		query, err := parsePointsHistoryQuery(c)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		result, err := h.pointsService.SearchPointsHistory(&userID, query)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
 	// Get pagination parameters
 	page := 1
 	pageSize := 20
@@ -88,6 +124,135 @@ func (h *PointsHandler) GetPointsTransactions(c *gin.Context) {
 	})
 }
 
+// parsePointsHistoryQuery reads points history filters and the cursor from query parameters
+// Dates accept RFC 3339 timestamps or YYYY-MM-DD; a date-only "to" includes that whole day
+func parsePointsHistoryQuery(c *gin.Context) (service.PointsHistoryQuery, error) {
+	var query service.PointsHistoryQuery
+
+	if typesStr := c.Query("type"); typesStr != "" {
+		for _, transactionType := range strings.Split(typesStr, ",") {
+			if transactionType = strings.TrimSpace(transactionType); transactionType != "" {
+				query.Types = append(query.Types, transactionType)
+			}
+		}
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, _, err := parseQueryTime(fromStr)
+		if err != nil {
+			return query, fmt.Errorf("invalid from date: %s", fromStr)
+		}
+		query.From = &from
+	}
+
+	if toStr := c.Query("to"); toStr != "" {
+		to, dateOnly, err := parseQueryTime(toStr)
+		if err != nil {
+			return query, fmt.Errorf("invalid to date: %s", toStr)
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		query.To = &to
+	}
+
+	if minStr := c.Query("min_amount"); minStr != "" {
+		minAmount, err := strconv.Atoi(minStr)
+		if err != nil || minAmount < 0 {
+			return query, fmt.Errorf("invalid min_amount: %s", minStr)
+		}
+		query.MinAmount = &minAmount
+	}
+
+	if maxStr := c.Query("max_amount"); maxStr != "" {
+		maxAmount, err := strconv.Atoi(maxStr)
+		if err != nil || maxAmount < 0 {
+			return query, fmt.Errorf("invalid max_amount: %s", maxStr)
+		}
+		query.MaxAmount = &maxAmount
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > 100 {
+			return query, fmt.Errorf("invalid limit: %s (must be 1-100)", limitStr)
+		}
+		query.Limit = limit
+	}
+
+	query.Wallet = c.Query("wallet")
+	query.Reason = strings.TrimSpace(c.Query("reason"))
+	query.Cursor = c.Query("cursor")
+
+	return query, nil
+}
+
+// parseQueryTime parses an RFC 3339 timestamp or a YYYY-MM-DD date in local time
+// The second return value reports whether the input was a date without a time
+func parseQueryTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	return t, true, err
+}
+
+// ListStatements lists the current user's stored monthly statements
+// GET /api/v1/points/statements
+func (h *PointsHandler) ListStatements(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
+	statements, err := h.statementService.ListStatements(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve points statements",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"statements": statements,
+	})
+}
+
+// GetStatement gets the current user's statement for a month, as JSON or HTML
+// GET /api/v1/points/statements/:period?format=html
+func (h *PointsHandler) GetStatement(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
+	view, err := h.statementService.GetStatement(userID, c.Param("period"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if c.Query("format") == "html" {
+		c.Status(http.StatusOK)
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		if err := statementTemplate.Execute(c.Writer, view); err != nil {
+			c.Error(err)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
 // RegisterRoutes registers points routes
 func (h *PointsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware gin.HandlerFunc) {
 	points := router.Group("/points")
@@ -95,5 +260,7 @@ func (h *PointsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware g
 	{
 		points.GET("/balance", h.GetPointsBalance)
 		points.GET("/transactions", h.GetPointsTransactions)
+		points.GET("/statements", h.ListStatements)
+		points.GET("/statements/:period", h.GetStatement)
 	}
 }
@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"awsome-shop/internal/middleware"
 	"awsome-shop/internal/service"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,25 +23,108 @@ func NewProductHandler(productService *service.ProductService) *ProductHandler {
 	}
 }
 
-// GetProducts gets all active products
-// GET /api/v1/products
+// GetProducts searches active products in the caller's language, one page at a time
+// GET /api/v1/products?q=耳机&category_id=1&tag=新品&tag=热门&min_points=100&max_points=500&in_stock=true&sort=price_asc&page=1&page_size=20
+// sort is one of relevance, newest, price_asc, price_desc or popularity
 func (h *ProductHandler) GetProducts(c *gin.Context) {
-	products, err := h.productService.GetActiveProducts()
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
+	query, err := parseProductCatalogQuery(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"error": "Failed to retrieve products",
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
 		})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"products": products,
-	})
+	result, err := h.productService.GetActiveProducts(userID, query)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
 }
 
-// GetProductByID gets a product by ID
+// parseProductCatalogQuery reads catalog search filters, sorting and pagination from query parameters
+func parseProductCatalogQuery(c *gin.Context) (service.ProductCatalogQuery, error) {
+	query := service.ProductCatalogQuery{
+		Keyword: c.Query("q"),
+		Tags:    c.QueryArray("tag"),
+		Sort:    c.Query("sort"),
+	}
+
+	if categoryIDStr := c.Query("category_id"); categoryIDStr != "" {
+		id, err := strconv.ParseUint(categoryIDStr, 10, 32)
+		if err != nil {
+			return query, errors.New("invalid category ID")
+		}
+		categoryID := uint(id)
+		query.CategoryID = &categoryID
+	}
+
+	if minStr := c.Query("min_points"); minStr != "" {
+		minPoints, err := strconv.Atoi(minStr)
+		if err != nil || minPoints < 0 {
+			return query, fmt.Errorf("invalid min_points: %s", minStr)
+		}
+		query.MinPoints = &minPoints
+	}
+
+	if maxStr := c.Query("max_points"); maxStr != "" {
+		maxPoints, err := strconv.Atoi(maxStr)
+		if err != nil || maxPoints < 0 {
+			return query, fmt.Errorf("invalid max_points: %s", maxStr)
+		}
+		query.MaxPoints = &maxPoints
+	}
+
+	if inStockStr := c.Query("in_stock"); inStockStr != "" {
+		inStock, err := strconv.ParseBool(inStockStr)
+		if err != nil {
+			return query, fmt.Errorf("invalid in_stock: %s", inStockStr)
+		}
+		query.InStockOnly = inStock
+	}
+
+	if pageStr := c.Query("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page <= 0 {
+			return query, fmt.Errorf("invalid page: %s", pageStr)
+		}
+		query.Page = page
+	}
+
+	if pageSizeStr := c.Query("page_size"); pageSizeStr != "" {
+		pageSize, err := strconv.Atoi(pageSizeStr)
+		if err != nil || pageSize <= 0 || pageSize > 100 {
+			return query, fmt.Errorf("invalid page_size: %s (must be 1-100)", pageSizeStr)
+		}
+		query.PageSize = pageSize
+	}
+
+	return query, nil
+}
+
+// GetProductByID gets a product by ID in the caller's language
 // GET /api/v1/products/:id
 func (h *ProductHandler) GetProductByID(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "User ID not found in context",
+		})
+		return
+	}
+
 	var uri struct {
 		ID uint `uri:"id" binding:"required"`
 	}
@@ -49,7 +136,7 @@ func (h *ProductHandler) GetProductByID(c *gin.Context) {
 		return
 	}
 
-	product, err := h.productService.GetProductByID(uri.ID)
+	product, err := h.productService.GetProductForUser(uri.ID, userID)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{
 			"error": "Product not found",
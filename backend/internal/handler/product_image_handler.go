@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"awsome-shop/internal/service"
+	"awsome-shop/internal/storage"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProductImageHandler handles product image uploads and serves stored files
+type ProductImageHandler struct {
+	imageService *service.ProductImageService
+}
+
+// NewProductImageHandler creates a new ProductImageHandler instance
+func NewProductImageHandler(imageService *service.ProductImageService) *ProductImageHandler {
+	return &ProductImageHandler{
+		imageService: imageService,
+	}
+}
+
+// UploadImage uploads an image and appends it to a product's images
+// POST /api/v1/admin/products/:id/images (multipart/form-data, field "file")
+func (h *ProductImageHandler) UploadImage(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	// Leave room for the multipart envelope around the file itself
+	maxBytes := h.imageService.MaxUploadBytes()
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": fmt.Sprintf("Image cannot be larger than %d MB", maxBytes>>20),
+			})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A multipart file field named 'file' is required",
+		})
+		return
+	}
+
+	if fileHeader.Size > maxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"error": fmt.Sprintf("Image cannot be larger than %d MB", maxBytes>>20),
+		})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read uploaded file",
+		})
+		return
+	}
+	defer file.Close()
+
+	image, err := h.imageService.UploadImage(uint(productID), file, fileHeader.Header.Get("Content-Type"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"image": image,
+	})
+}
+
+// ReorderImagesRequest represents a request to reorder a product's images
+type ReorderImagesRequest struct {
+	ImageIDs []uint `json:"image_ids" binding:"required"`
+}
+
+// ReorderImages sets the display order of a product's images; the first becomes the main image
+// PUT /api/v1/admin/products/:id/images/order
+func (h *ProductImageHandler) ReorderImages(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	var req ReorderImagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	images, err := h.imageService.ReorderImages(uint(productID), req.ImageIDs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"images": images,
+	})
+}
+
+// DeleteImage removes one of a product's images
+// DELETE /api/v1/admin/products/:id/images/:image_id
+func (h *ProductImageHandler) DeleteImage(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid product ID",
+		})
+		return
+	}
+
+	imageID, err := strconv.ParseUint(c.Param("image_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid image ID",
+		})
+		return
+	}
+
+	err = h.imageService.DeleteImage(uint(productID), uint(imageID))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Image deleted successfully",
+	})
+}
+
+// ServeFile serves a stored file with long-lived caching headers
+// GET /api/v1/media/*key
+// Keys are random and never reused, so files can be cached as immutable. Conditional and
+// range requests are handled by http.ServeContent.
+func (h *ProductImageHandler) ServeFile(c *gin.Context) {
+	key := strings.TrimPrefix(c.Param("key"), "/")
+
+	object, err := h.imageService.OpenFile(key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "File not found",
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to read file",
+		})
+		return
+	}
+	defer object.Content.Close()
+
+	if object.ContentType != "" {
+		c.Header("Content-Type", object.ContentType)
+	}
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", h.imageService.CacheMaxAgeSeconds()))
+	c.Header("ETag", fmt.Sprintf(`"%x-%x"`, object.ModTime.Unix(), object.Size))
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(c.Writer, c.Request, key, object.ModTime, object.Content)
+}
+
+// RegisterRoutes registers product image and media routes
+// Media is served without authentication so that images can be used directly in <img> tags.
+func (h *ProductImageHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	router.GET("/media/*key", h.ServeFile)
+
+	admin := router.Group("/admin/products")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.POST("/:id/images", h.UploadImage)
+		admin.PUT("/:id/images/order", h.ReorderImages)
+		admin.DELETE("/:id/images/:image_id", h.DeleteImage)
+	}
+}
@@ -0,0 +1,188 @@
+package handler
+
+import (
+	"awsome-shop/internal/service"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReasonCodeHandler handles reason code catalog requests
+type ReasonCodeHandler struct {
+	reasonCodeService *service.ReasonCodeService
+}
+
+// NewReasonCodeHandler creates a new ReasonCodeHandler instance
+func NewReasonCodeHandler(reasonCodeService *service.ReasonCodeService) *ReasonCodeHandler {
+	return &ReasonCodeHandler{
+		reasonCodeService: reasonCodeService,
+	}
+}
+
+// ReasonCodeRequest represents a request to create or update a reason code
+type ReasonCodeRequest struct {
+	Code         string `json:"code"` // Required on create; cannot be changed afterwards
+	LabelZh      string `json:"label_zh" binding:"required"`
+	LabelEn      string `json:"label_en" binding:"required"`
+	NoteRequired bool   `json:"note_required"`
+}
+
+// toServiceRequest converts the handler request to a service request
+func (r *ReasonCodeRequest) toServiceRequest() *service.ReasonCodeRequest {
+	return &service.ReasonCodeRequest{
+		Code:         r.Code,
+		LabelZh:      r.LabelZh,
+		LabelEn:      r.LabelEn,
+		NoteRequired: r.NoteRequired,
+	}
+}
+
+// ListActiveReasonCodes lists the reason codes that can be used for new transactions
+// GET /api/v1/reason-codes
+func (h *ReasonCodeHandler) ListActiveReasonCodes(c *gin.Context) {
+	status := "active"
+	reasonCodes, err := h.reasonCodeService.ListReasonCodes(&status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve reason codes",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reason_codes": reasonCodes,
+	})
+}
+
+// CreateReasonCode adds a reason code to the catalog
+// POST /api/v1/admin/reason-codes
+func (h *ReasonCodeHandler) CreateReasonCode(c *gin.Context) {
+	var req ReasonCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	reasonCode, err := h.reasonCodeService.CreateReasonCode(req.toServiceRequest())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"reason_code": reasonCode,
+	})
+}
+
+// ListReasonCodes lists reason codes with optional status filter
+// GET /api/v1/admin/reason-codes?status=active
+func (h *ReasonCodeHandler) ListReasonCodes(c *gin.Context) {
+	var status *string
+	if statusStr := c.Query("status"); statusStr != "" {
+		status = &statusStr
+	}
+
+	reasonCodes, err := h.reasonCodeService.ListReasonCodes(status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve reason codes",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reason_codes": reasonCodes,
+	})
+}
+
+// UpdateReasonCode updates a reason code's labels and note requirement
+// PUT /api/v1/admin/reason-codes/:id
+func (h *ReasonCodeHandler) UpdateReasonCode(c *gin.Context) {
+	reasonCodeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid reason code ID",
+		})
+		return
+	}
+
+	var req ReasonCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format",
+		})
+		return
+	}
+
+	reasonCode, err := h.reasonCodeService.UpdateReasonCode(uint(reasonCodeID), req.toServiceRequest())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reason_code": reasonCode,
+	})
+}
+
+// SetReasonCodeStatusRequest represents a request to set reason code status
+type SetReasonCodeStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active inactive"`
+}
+
+// SetReasonCodeStatus sets a reason code's status (active/inactive)
+// PUT /api/v1/admin/reason-codes/:id/status
+func (h *ReasonCodeHandler) SetReasonCodeStatus(c *gin.Context) {
+	reasonCodeID, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid reason code ID",
+		})
+		return
+	}
+
+	var req SetReasonCodeStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Invalid request format. Status must be 'active' or 'inactive'",
+		})
+		return
+	}
+
+	err = h.reasonCodeService.SetReasonCodeStatus(uint(reasonCodeID), req.Status)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Reason code status updated successfully",
+	})
+}
+
+// RegisterRoutes registers reason code routes
+func (h *ReasonCodeHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware, adminMiddleware gin.HandlerFunc) {
+	reasonCodes := router.Group("/reason-codes")
+	reasonCodes.Use(authMiddleware)
+	{
+		reasonCodes.GET("", h.ListActiveReasonCodes)
+	}
+
+	admin := router.Group("/admin/reason-codes")
+	admin.Use(authMiddleware, adminMiddleware)
+	{
+		admin.POST("", h.CreateReasonCode)
+		admin.GET("", h.ListReasonCodes)
+		admin.PUT("/:id", h.UpdateReasonCode)
+		admin.PUT("/:id/status", h.SetReasonCodeStatus)
+	}
+}
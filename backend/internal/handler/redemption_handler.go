@@ -22,7 +22,8 @@ func NewRedemptionHandler(redemptionService *service.RedemptionService) *Redempt
 
 // RedeemProductRequest represents a request to redeem a product
 type RedeemProductRequest struct {
-	ProductID uint `json:"product_id" binding:"required"`
+	ProductID uint  `json:"product_id" binding:"required"`
+	VariantID *uint `json:"variant_id"` // Required when the product has variants
 }
 
 // CreateRedemption creates a new redemption order
@@ -45,7 +46,7 @@ func (h *RedemptionHandler) CreateRedemption(c *gin.Context) {
 	}
 
 	// Process redemption
-	order, err := h.redemptionService.RedeemProduct(userID, req.ProductID)
+	order, err := h.redemptionService.RedeemProduct(userID, req.ProductID, req.VariantID)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"html/template"
+)
+
+// statementTemplate renders a points statement as a printable HTML page
+var statementTemplate = template.Must(template.New("statement").Funcs(template.FuncMap{
+	"date": func(t interface{ Format(string) string }) string {
+		return t.Format("2006-01-02 15:04")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>积分对账单 / Points Statement {{.Statement.Period}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-top: 1em; }
+th, td { border: 1px solid #ccc; padding: 6px 10px; text-align: left; }
+td.amount { text-align: right; }
+.provisional { color: #b35900; }
+</style>
+</head>
+<body>
+<h1>积分对账单 / Points Statement {{.Statement.Period}}</h1>
+{{if .Provisional}}<p class="provisional">本月尚未结束，对账单为临时数据 / This period is still open; figures are provisional.</p>{{end}}
+<table>
+<tr><th>期初余额 / Opening balance</th><td class="amount">{{.Statement.OpeningBalance}}</td></tr>
+<tr><th>收入 / Credits</th><td class="amount">{{.Statement.TotalCredits}}</td></tr>
+<tr><th>支出 / Debits</th><td class="amount">{{.Statement.TotalDebits}}</td></tr>
+<tr><th>期末余额 / Closing balance</th><td class="amount">{{.Statement.ClosingBalance}}</td></tr>
+</table>
+<table>
+<tr><th>时间 / Date</th><th>类型 / Type</th><th>说明 / Reason</th><th>金额 / Amount</th><th>余额 / Balance</th></tr>
+{{range .Transactions}}<tr><td>{{date .CreatedAt}}</td><td>{{.TransactionType}}</td><td>{{.Reason}}</td><td class="amount">{{.Amount}}</td><td class="amount">{{.BalanceAfter}}</td></tr>
+{{else}}<tr><td colspan="5">本期无交易 / No transactions in this period</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,162 @@
+package middleware
+
+import (
+	"awsome-shop/internal/service"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader is the request header carrying a client-generated idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength matches the size of the idempotency_keys.key column
+const maxIdempotencyKeyLength = 255
+
+// responseCaptureWriter records the response body while still writing it to the client
+type responseCaptureWriter struct {
+	gin.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (w *responseCaptureWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseCaptureWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware creates a middleware that replays responses for repeated Idempotency-Key headers
+// Keys are scoped to the authenticated user. A retry with the same key and payload receives the
+// stored response; the same key with a different payload is rejected.
+func IdempotencyMiddleware(authService *service.AuthService, idempotencyService *service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Idempotency-Key must be at most 255 characters",
+			})
+			c.Abort()
+			return
+		}
+
+		// Unauthenticated requests are left for the auth middleware to reject
+		userID, ok := userIDFromBearerToken(c, authService)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		// The whole body is buffered to fingerprint it, so cap its size
+		maxBytes := idempotencyService.MaxBodyBytes()
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+					"error": fmt.Sprintf("Request body cannot be larger than %d MB", maxBytes>>20),
+				})
+				c.Abort()
+				return
+			}
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Failed to read request body",
+			})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		path := c.Request.URL.RequestURI()
+		requestHash := service.HashRequest(c.Request.Method, path, body)
+
+		record, replay, err := idempotencyService.Begin(userID, key, c.Request.Method, path, requestHash)
+		if err != nil {
+			switch {
+			case errors.Is(err, service.ErrIdempotencyKeyReused):
+				c.JSON(http.StatusUnprocessableEntity, gin.H{
+					"error": err.Error(),
+				})
+			case errors.Is(err, service.ErrIdempotencyRequestInProgress):
+				c.JSON(http.StatusConflict, gin.H{
+					"error": err.Error(),
+				})
+			default:
+				c.JSON(http.StatusInternalServerError, gin.H{
+					"error": "Failed to process idempotency key",
+				})
+			}
+			c.Abort()
+			return
+		}
+
+		if replay {
+			c.Header("Idempotent-Replayed", "true")
+			contentType := record.ResponseContentType
+			if contentType == "" {
+				// Responses stored before the content type was recorded were all JSON
+				contentType = "application/json; charset=utf-8"
+			}
+			c.Data(record.ResponseStatus, contentType, []byte(record.ResponseBody))
+			c.Abort()
+			return
+		}
+
+		// Release the key if the handler panics so the client can retry
+		defer func() {
+			if r := recover(); r != nil {
+				if err := idempotencyService.Release(record); err != nil {
+					log.Printf("Failed to release idempotency key: %v", err)
+				}
+				panic(r)
+			}
+		}()
+
+		writer := &responseCaptureWriter{ResponseWriter: c.Writer, body: &bytes.Buffer{}}
+		c.Writer = writer
+
+		c.Next()
+
+		if err := idempotencyService.Complete(record, writer.Status(), writer.Header().Get("Content-Type"), writer.body.String()); err != nil {
+			log.Printf("Failed to store idempotent response: %v", err)
+		}
+	}
+}
+
+// isMutatingMethod reports whether an HTTP method can change server state
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// userIDFromBearerToken extracts the user ID from a valid Bearer token without aborting the request
+func userIDFromBearerToken(c *gin.Context, authService *service.AuthService) (uint, bool) {
+	parts := strings.SplitN(c.GetHeader("Authorization"), " ", 2)
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return 0, false
+	}
+
+	claims, err := authService.ValidateToken(parts[1])
+	if err != nil {
+		return 0, false
+	}
+
+	return claims.UserID, true
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// Campaign represents a time-limited promotion applied at redemption checkout
+type Campaign struct {
+	ID              uint       `gorm:"primaryKey" json:"id"`
+	Name            string     `gorm:"size:200;not null" json:"name"`
+	Description     string     `gorm:"size:1000" json:"description"`
+	StartsAt        time.Time  `gorm:"not null;index" json:"starts_at"`
+	EndsAt          time.Time  `gorm:"not null;index" json:"ends_at"`
+	RuleType        string     `gorm:"type:enum('discount','bonus_points');not null" json:"rule_type"`
+	DiscountPercent int        `gorm:"default:0" json:"discount_percent"` // Percent off PointsRequired for discount campaigns
+	BonusPoints     int        `gorm:"default:0" json:"bonus_points"`     // Points granted back per redemption for bonus_points campaigns
+	AppliesToAll    bool       `gorm:"default:false" json:"applies_to_all"`
+	Products        []Product  `gorm:"many2many:campaign_products" json:"products,omitempty"`
+	Categories      []Category `gorm:"many2many:campaign_categories" json:"categories,omitempty"` // Also covers products in subcategories
+	Status          string     `gorm:"type:enum('active','inactive');default:'active'" json:"status"`
+	CreatedBy       *uint      `json:"created_by"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for Campaign model
+func (Campaign) TableName() string {
+	return "campaigns"
+}
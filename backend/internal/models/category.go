@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// Category is a node in the hierarchical product catalog
+// Top-level categories have no parent.
+type Category struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	ParentID  *uint      `gorm:"index" json:"parent_id"`
+	Name      string     `gorm:"size:100;not null" json:"name"`
+	SortOrder int        `gorm:"default:0" json:"sort_order"`
+	Children  []Category `gorm:"-" json:"children,omitempty"` // Filled in when listing the category tree
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for Category model
+func (Category) TableName() string {
+	return "categories"
+}
+
+// Tag is a free-form product label
+type Tag struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	Name      string    `gorm:"size:50;not null;uniqueIndex" json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for Tag model
+func (Tag) TableName() string {
+	return "tags"
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// DataMigration records a one-time data fix the application applied at startup
+// Fixes that must not run again, such as sealing the points ledger, check for their row first.
+type DataMigration struct {
+	Name      string    `gorm:"primaryKey;size:100" json:"name"`
+	AppliedAt time.Time `gorm:"not null" json:"applied_at"`
+}
+
+// TableName specifies the table name for DataMigration model
+func (DataMigration) TableName() string {
+	return "data_migrations"
+}
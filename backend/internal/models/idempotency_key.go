@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// IdempotencyKey stores the outcome of a mutating request so client retries can be replayed
+type IdempotencyKey struct {
+	ID                  uint      `gorm:"primaryKey" json:"id"`
+	UserID              uint      `gorm:"not null;uniqueIndex:idx_user_key" json:"user_id"`
+	Key                 string    `gorm:"size:255;not null;uniqueIndex:idx_user_key" json:"key"`
+	Method              string    `gorm:"size:10;not null" json:"method"`
+	Path                string    `gorm:"size:500;not null" json:"path"`
+	RequestHash         string    `gorm:"size:64;not null" json:"request_hash"`
+	ResponseStatus      int       `gorm:"default:0" json:"response_status"` // 0 while the request is in progress
+	ResponseContentType string    `gorm:"size:255;not null;default:''" json:"response_content_type"`
+	ResponseBody        string    `gorm:"type:mediumtext" json:"response_body"`
+	ExpiresAt           time.Time `gorm:"not null;index" json:"expires_at"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for IdempotencyKey model
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
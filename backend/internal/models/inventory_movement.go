@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// Inventory movement types
+const (
+	MovementReceive  = "receive"  // New stock received
+	MovementRedeem   = "redeem"   // Taken by a redemption
+	MovementAdjust   = "adjust"   // Correction after a count, or a stock edit without a reason
+	MovementReturn   = "return"   // Returned to stock from an order
+	MovementWriteoff = "writeoff" // Damaged, lost or expired stock removed
+)
+
+// InventoryMovement records one change to a product's stock and why it happened
+// Quantity is always the change to the product's total stock. For a variant movement, StockAfter
+// is the variant's stock; ProductStockAfter is always the product's total stock after the movement.
+type InventoryMovement struct {
+	ID                uint             `gorm:"primaryKey" json:"id"`
+	ProductID         uint             `gorm:"not null;index" json:"product_id"`
+	VariantID         *uint            `gorm:"index" json:"variant_id"`
+	VariantLabel      string           `gorm:"size:200" json:"variant_label"` // Snapshot of the variant's label
+	MovementType      string           `gorm:"type:enum('receive','redeem','adjust','return','writeoff');not null" json:"movement_type"`
+	Quantity          int              `gorm:"not null" json:"quantity"` // Positive adds stock, negative removes it
+	StockAfter        int              `gorm:"not null" json:"stock_after"`
+	ProductStockAfter int              `gorm:"not null" json:"product_stock_after"`
+	OperatorID        *uint            `json:"operator_id"` // Nil for redemptions by employees
+	Operator          *User            `gorm:"foreignKey:OperatorID" json:"operator,omitempty"`
+	RelatedOrderID    *uint            `gorm:"index" json:"related_order_id"`
+	RelatedOrder      *RedemptionOrder `gorm:"foreignKey:RelatedOrderID" json:"related_order,omitempty"`
+	Note              string           `gorm:"size:500" json:"note"`
+	CreatedAt         time.Time        `gorm:"index" json:"created_at"`
+}
+
+// TableName specifies the table name for InventoryMovement model
+func (InventoryMovement) TableName() string {
+	return "inventory_movements"
+}
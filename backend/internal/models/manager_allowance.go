@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// ManagerAllowance is a points budget a manager can grant to their direct reports
+type ManagerAllowance struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ManagerID uint      `gorm:"not null;index" json:"manager_id"`
+	Manager   User      `gorm:"foreignKey:ManagerID" json:"manager,omitempty"`
+	Wallet    string    `gorm:"type:enum('benefit','recognition');not null;default:'recognition'" json:"wallet"`
+	Amount    int       `gorm:"not null" json:"amount"`
+	Used      int       `gorm:"not null;default:0" json:"used"`
+	StartsAt  time.Time `gorm:"not null" json:"starts_at"`
+	EndsAt    time.Time `gorm:"not null" json:"ends_at"`
+	Status    string    `gorm:"type:enum('active','inactive');default:'active'" json:"status"`
+	Note      string    `gorm:"size:500" json:"note"`
+	CreatedBy *uint     `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for ManagerAllowance model
+func (ManagerAllowance) TableName() string {
+	return "manager_allowances"
+}
+
+// Remaining returns the points still available in the allowance
+func (a *ManagerAllowance) Remaining() int {
+	return a.Amount - a.Used
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// Notification types
+const (
+	NotificationLowStock = "low_stock"
+)
+
+// Notification is an in-app message for one user
+// Text is rendered in the recipient's preferred language when the notification is created.
+type Notification struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index:idx_notifications_user_read" json:"user_id"`
+	Type      string     `gorm:"size:50;not null" json:"type"`
+	Title     string     `gorm:"size:200;not null" json:"title"`
+	Body      string     `gorm:"size:1000" json:"body"`
+	ProductID *uint      `json:"product_id"` // Product the notification is about, if any
+	ReadAt    *time.Time `gorm:"index:idx_notifications_user_read" json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// TableName specifies the table name for Notification model
+func (Notification) TableName() string {
+	return "notifications"
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// PointsGrantApproval is a grant to an admin held until a second admin approves it
+type PointsGrantApproval struct {
+	ID            uint        `gorm:"primaryKey" json:"id"`
+	UserID        uint        `gorm:"not null;index" json:"user_id"`
+	User          User        `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Wallet        string      `gorm:"type:enum('benefit','recognition');not null;default:'benefit'" json:"wallet"`
+	Amount        int         `gorm:"not null" json:"amount"`
+	Reason        string      `gorm:"size:500" json:"reason"`
+	ReasonCodeID  *uint       `json:"reason_code_id"`
+	ReasonCode    *ReasonCode `gorm:"foreignKey:ReasonCodeID" json:"reason_code,omitempty"`
+	RequestedBy   uint        `gorm:"not null" json:"requested_by"`
+	Requester     User        `gorm:"foreignKey:RequestedBy" json:"requester,omitempty"`
+	Status        string      `gorm:"type:enum('pending','approved','rejected');default:'pending';index" json:"status"`
+	ReviewedBy    *uint       `json:"reviewed_by"`
+	Reviewer      *User       `gorm:"foreignKey:ReviewedBy" json:"reviewer,omitempty"`
+	ReviewNote    string      `gorm:"size:500" json:"review_note"`
+	ReviewedAt    *time.Time  `json:"reviewed_at"`
+	TransactionID *uint       `json:"transaction_id"` // Grant written on approval
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// TableName specifies the table name for PointsGrantApproval model
+func (PointsGrantApproval) TableName() string {
+	return "points_grant_approvals"
+}
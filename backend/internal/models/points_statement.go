@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// PointsStatement is an employee's points summary for one calendar month
+// Statements are only stored once the month has closed and are never updated afterwards.
+type PointsStatement struct {
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;uniqueIndex:idx_user_period" json:"user_id"`
+	User             User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	Period           string    `gorm:"size:7;not null;uniqueIndex:idx_user_period" json:"period"` // YYYY-MM
+	PeriodStart      time.Time `gorm:"not null" json:"period_start"`
+	PeriodEnd        time.Time `gorm:"not null" json:"period_end"` // Exclusive
+	OpeningBalance   int       `gorm:"not null" json:"opening_balance"`
+	TotalCredits     int       `gorm:"not null" json:"total_credits"`
+	TotalDebits      int       `gorm:"not null" json:"total_debits"` // Positive sum of negative amounts
+	ClosingBalance   int       `gorm:"not null" json:"closing_balance"`
+	TransactionCount int       `gorm:"not null" json:"transaction_count"`
+	LedgerHash       string    `gorm:"size:64;not null;default:''" json:"ledger_hash"` // Hash of the last ledger entry on or before the period end
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for PointsStatement model
+func (PointsStatement) TableName() string {
+	return "points_statements"
+}
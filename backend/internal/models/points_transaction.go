@@ -1,7 +1,13 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PointsTransaction represents a points transaction in the system
@@ -9,14 +15,21 @@ type PointsTransaction struct {
 	ID              uint             `gorm:"primaryKey" json:"id"`
 	UserID          uint             `gorm:"not null" json:"user_id"`
 	User            User             `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	TransactionType string           `gorm:"type:enum('grant','deduct','redemption');not null" json:"transaction_type"`
+	TransactionType string           `gorm:"type:enum('grant','deduct','redemption','adjustment','reversal');not null" json:"transaction_type"`
+	Wallet          string           `gorm:"type:enum('benefit','recognition');not null;default:'benefit';index" json:"wallet"`
 	Amount          int              `gorm:"not null" json:"amount"`
 	BalanceAfter    int              `gorm:"not null" json:"balance_after"`
 	Reason          string           `gorm:"size:500" json:"reason"`
+	ReasonCodeID    *uint            `gorm:"index" json:"reason_code_id"`
+	ReasonCode      *ReasonCode      `gorm:"foreignKey:ReasonCodeID" json:"reason_code,omitempty"`
 	OperatorID      *uint            `json:"operator_id"`
 	Operator        *User            `gorm:"foreignKey:OperatorID" json:"operator,omitempty"`
 	RelatedOrderID  *uint            `json:"related_order_id"`
 	RelatedOrder    *RedemptionOrder `gorm:"foreignKey:RelatedOrderID" json:"related_order,omitempty"`
+	ReversalOfID    *uint            `gorm:"uniqueIndex" json:"reversal_of_id"`            // Original entry this one reverses; unique so an entry is reversed at most once
+	AllowanceID     *uint            `gorm:"index" json:"allowance_id"`                    // Manager allowance a grant was drawn from
+	PrevHash        string           `gorm:"size:64;not null;default:''" json:"prev_hash"` // Hash of the user's previous entry, empty for the first one
+	Hash            string           `gorm:"size:64;not null;default:''" json:"hash"`      // SHA-256 over this entry's content and PrevHash
 	CreatedAt       time.Time        `json:"created_at"`
 }
 
@@ -24,3 +37,77 @@ type PointsTransaction struct {
 func (PointsTransaction) TableName() string {
 	return "points_transactions"
 }
+
+// ComputeHash calculates the chain hash of a transaction from its content and PrevHash
+// Every stored field is hashed, in one fixed shape, so no field can be changed without
+// breaking the chain. The ID is not part of the hash since it is assigned by the database
+// after hashing.
+func (t *PointsTransaction) ComputeHash() string {
+	content, _ := json.Marshal(struct {
+		UserID          uint   `json:"user_id"`
+		TransactionType string `json:"transaction_type"`
+		Wallet          string `json:"wallet"`
+		Amount          int    `json:"amount"`
+		BalanceAfter    int    `json:"balance_after"`
+		Reason          string `json:"reason"`
+		ReasonCodeID    *uint  `json:"reason_code_id"`
+		OperatorID      *uint  `json:"operator_id"`
+		RelatedOrderID  *uint  `json:"related_order_id"`
+		ReversalOfID    *uint  `json:"reversal_of_id"`
+		AllowanceID     *uint  `json:"allowance_id"`
+		CreatedAt       int64  `json:"created_at"`
+		PrevHash        string `json:"prev_hash"`
+	}{
+		UserID:          t.UserID,
+		TransactionType: t.TransactionType,
+		Wallet:          t.Wallet,
+		Amount:          t.Amount,
+		BalanceAfter:    t.BalanceAfter,
+		Reason:          t.Reason,
+		ReasonCodeID:    t.ReasonCodeID,
+		OperatorID:      t.OperatorID,
+		RelatedOrderID:  t.RelatedOrderID,
+		ReversalOfID:    t.ReversalOfID,
+		AllowanceID:     t.AllowanceID,
+		CreatedAt:       t.CreatedAt.Unix(),
+		PrevHash:        t.PrevHash,
+	})
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// BeforeCreate links a new transaction to the end of its user's hash chain
+// This runs for every insert, so no code path can write an unchained entry. The previous
+// entry is read FOR UPDATE, which serializes concurrent appends for the same user.
+func (t *PointsTransaction) BeforeCreate(tx *gorm.DB) error {
+	// Timestamps are stored with second precision, so hash exactly what will be read back
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = time.Now()
+	}
+	t.CreatedAt = t.CreatedAt.Truncate(time.Second)
+
+	if t.Wallet == "" {
+		t.Wallet = WalletBenefit
+	}
+
+	var previous []PointsTransaction
+	err := tx.Session(&gorm.Session{NewDB: true}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "hash").
+		Where("user_id = ?", t.UserID).
+		Order("id DESC").
+		Limit(1).
+		Find(&previous).Error
+	if err != nil {
+		return err
+	}
+
+	t.PrevHash = ""
+	if len(previous) > 0 {
+		t.PrevHash = previous[0].Hash
+	}
+	t.Hash = t.ComputeHash()
+
+	return nil
+}
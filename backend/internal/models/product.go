@@ -4,19 +4,107 @@ import (
 	"time"
 )
 
+// Supported content languages
+const (
+	LanguageZh = "zh"
+	LanguageEn = "en"
+)
+
 // Product represents a product in the system
 type Product struct {
-	ID             uint      `gorm:"primaryKey" json:"id"`
-	Name           string    `gorm:"size:200;not null" json:"name"`
-	ImageURL       string    `gorm:"size:500" json:"image_url"`
-	PointsRequired int       `gorm:"not null" json:"points_required"`
-	StockQuantity  int       `gorm:"default:0" json:"stock_quantity"`
-	Status         string    `gorm:"type:enum('active','inactive');default:'active'" json:"status"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID                 uint             `gorm:"primaryKey" json:"id"`
+	Name               string           `gorm:"size:200;not null" json:"name"` // Display name; set to the caller's locale when served
+	NameZh             string           `gorm:"size:200" json:"name_zh"`
+	NameEn             string           `gorm:"size:200" json:"name_en"`
+	ShortDescriptionZh string           `gorm:"size:500" json:"short_description_zh"`
+	ShortDescriptionEn string           `gorm:"size:500" json:"short_description_en"`
+	DescriptionZh      string           `gorm:"type:text" json:"description_zh"` // Sanitized Markdown
+	DescriptionEn      string           `gorm:"type:text" json:"description_en"` // Sanitized Markdown
+	ShortDescription   string           `gorm:"-" json:"short_description"`      // Caller's locale, filled in by Localize
+	Description        string           `gorm:"-" json:"description"`            // Caller's locale, filled in by Localize
+	ImageURL           string           `gorm:"size:500" json:"image_url"`
+	PointsRequired     int              `gorm:"not null" json:"points_required"`
+	PreviousPoints     *int             `gorm:"-" json:"previous_points,omitempty"` // Price before a recent drop, filled in for employees
+	PriceDropped       bool             `gorm:"-" json:"price_dropped"`
+	StockQuantity      int              `gorm:"default:0" json:"stock_quantity"`
+	LowStockThreshold  int              `gorm:"not null;default:0" json:"low_stock_threshold"` // Admins are alerted when stock falls to this level
+	Status             string           `gorm:"type:enum('active','inactive');default:'active'" json:"status"`
+	AcceptedWallets    string           `gorm:"type:set('benefit','recognition');not null;default:'benefit,recognition'" json:"accepted_wallets"` // Comma-separated wallet types
+	CategoryID         *uint            `gorm:"index" json:"category_id"`
+	Category           *Category        `gorm:"foreignKey:CategoryID" json:"category,omitempty"`
+	Tags               []Tag            `gorm:"many2many:product_tags" json:"tags"`
+	Variants           []ProductVariant `gorm:"foreignKey:ProductID" json:"variants,omitempty"`
+	Images             []ProductImage   `gorm:"foreignKey:ProductID" json:"images,omitempty"`
+	AvailableFrom      *time.Time       `gorm:"index" json:"available_from"`  // Hidden from the catalog before this time
+	AvailableUntil     *time.Time       `gorm:"index" json:"available_until"` // Hidden from the catalog from this time on
+	PublishedAt        *time.Time       `json:"published_at"`                 // When the availability job last activated the product
+	UnpublishedAt      *time.Time       `json:"unpublished_at"`               // When the availability job last deactivated the product
+	DeactivatedAt      *time.Time       `json:"deactivated_at"`               // When an admin last deactivated the product by hand
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
 }
 
 // TableName specifies the table name for Product model
 func (Product) TableName() string {
 	return "products"
 }
+
+// pickLocale returns the text for language, falling back to the other locale when it is empty
+func pickLocale(language, zh, en string) string {
+	if language == LanguageEn {
+		if en != "" {
+			return en
+		}
+		return zh
+	}
+
+	if zh != "" {
+		return zh
+	}
+	return en
+}
+
+// LocalizedName returns the product name for language
+// Products created before per-locale names existed fall back to the stored Name.
+func (p *Product) LocalizedName(language string) string {
+	if name := pickLocale(language, p.NameZh, p.NameEn); name != "" {
+		return name
+	}
+	return p.Name
+}
+
+// Localize fills Name, ShortDescription and Description with the content for language
+// The product must not be saved afterwards, since Name is a stored column.
+func (p *Product) Localize(language string) {
+	p.Name = p.LocalizedName(language)
+	p.ShortDescription = pickLocale(language, p.ShortDescriptionZh, p.ShortDescriptionEn)
+	p.Description = pickLocale(language, p.DescriptionZh, p.DescriptionEn)
+}
+
+// IsAvailableAt reports whether t falls inside the product's availability window
+// Status is checked separately; a product without a window is always available.
+func (p *Product) IsAvailableAt(t time.Time) bool {
+	if p.AvailableFrom != nil && t.Before(*p.AvailableFrom) {
+		return false
+	}
+	if p.AvailableUntil != nil && !t.Before(*p.AvailableUntil) {
+		return false
+	}
+	return true
+}
+
+// DueToPublish reports whether the availability job should activate the product at now
+// The job acts once per window opening: it skips a product it has already published for the
+// current window, and one an admin deactivated by hand after the window opened.
+func (p *Product) DueToPublish(now time.Time) bool {
+	if p.Status != "inactive" || p.AvailableFrom == nil || !p.IsAvailableAt(now) {
+		return false
+	}
+	if p.PublishedAt != nil && !p.PublishedAt.Before(*p.AvailableFrom) {
+		return false
+	}
+	if p.DeactivatedAt != nil && !p.DeactivatedAt.Before(*p.AvailableFrom) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// ProductImage is an uploaded product image together with its thumbnail
+// Images are shown in SortOrder; the first one is mirrored into Product.ImageURL.
+type ProductImage struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ProductID    uint      `gorm:"not null;index" json:"product_id"`
+	StorageKey   string    `gorm:"size:255;not null" json:"-"`
+	ThumbnailKey string    `gorm:"size:255;not null" json:"-"`
+	URL          string    `gorm:"size:500;not null" json:"url"`
+	ThumbnailURL string    `gorm:"size:500;not null" json:"thumbnail_url"`
+	ContentType  string    `gorm:"size:50;not null" json:"content_type"`
+	SizeBytes    int64     `gorm:"not null" json:"size_bytes"`
+	Width        int       `gorm:"not null" json:"width"`
+	Height       int       `gorm:"not null" json:"height"`
+	SortOrder    int       `gorm:"default:0" json:"sort_order"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for ProductImage model
+func (ProductImage) TableName() string {
+	return "product_images"
+}
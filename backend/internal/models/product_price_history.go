@@ -13,7 +13,7 @@ type ProductPriceHistory struct {
 	NewPoints  int       `gorm:"not null" json:"new_points"`
 	OperatorID *uint     `json:"operator_id"`
 	Operator   *User     `gorm:"foreignKey:OperatorID" json:"operator,omitempty"`
-	CreatedAt  time.Time `json:"created_at"`
+	CreatedAt  time.Time `gorm:"index" json:"created_at"`
 }
 
 // TableName specifies the table name for ProductPriceHistory model
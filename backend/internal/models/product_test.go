@@ -0,0 +1,70 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProductDueToPublish(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	opened := now.Add(-time.Hour)
+	closes := now.Add(time.Hour)
+	publishedForWindow := opened.Add(time.Minute)
+	deactivatedByHand := opened.Add(30 * time.Minute)
+	before := opened.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		product Product
+		want    bool
+	}{
+		{
+			name:    "window opened, never published",
+			product: Product{Status: "inactive", AvailableFrom: &opened, AvailableUntil: &closes},
+			want:    true,
+		},
+		{
+			name:    "window not open yet",
+			product: Product{Status: "inactive", AvailableFrom: &closes},
+			want:    false,
+		},
+		{
+			name:    "already active",
+			product: Product{Status: "active", AvailableFrom: &opened},
+			want:    false,
+		},
+		{
+			name:    "no window",
+			product: Product{Status: "inactive"},
+			want:    false,
+		},
+		{
+			name:    "already published for this window",
+			product: Product{Status: "inactive", AvailableFrom: &opened, PublishedAt: &publishedForWindow},
+			want:    false,
+		},
+		{
+			name:    "deactivated by hand inside its window",
+			product: Product{Status: "inactive", AvailableFrom: &opened, PublishedAt: &publishedForWindow, DeactivatedAt: &deactivatedByHand},
+			want:    false,
+		},
+		{
+			name:    "deactivated by hand inside its window without a publish stamp",
+			product: Product{Status: "inactive", AvailableFrom: &opened, DeactivatedAt: &deactivatedByHand},
+			want:    false,
+		},
+		{
+			name:    "deactivated before the window was moved later",
+			product: Product{Status: "inactive", AvailableFrom: &opened, PublishedAt: &before, DeactivatedAt: &before},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.product.DueToPublish(now); got != tt.want {
+				t.Errorf("DueToPublish() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
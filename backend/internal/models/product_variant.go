@@ -0,0 +1,50 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// VariantAttribute is one distinguishing attribute of a variant, such as size or color
+type VariantAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ProductVariant is a redeemable version of a product, such as one size of a T-shirt
+// A product with active variants tracks stock per variant, and its own StockQuantity is kept
+// as the sum of its active variants' stock.
+type ProductVariant struct {
+	ID             uint               `gorm:"primaryKey" json:"id"`
+	ProductID      uint               `gorm:"not null;index" json:"product_id"`
+	Label          string             `gorm:"size:200;not null" json:"label"` // Attribute values joined with " / "
+	Attributes     []VariantAttribute `gorm:"serializer:json;type:json" json:"attributes"`
+	PointsOverride *int               `json:"points_override"` // Nil uses the product's PointsRequired
+	StockQuantity  int                `gorm:"default:0" json:"stock_quantity"`
+	Status         string             `gorm:"type:enum('active','inactive');default:'active'" json:"status"`
+	SortOrder      int                `gorm:"default:0" json:"sort_order"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
+// TableName specifies the table name for ProductVariant model
+func (ProductVariant) TableName() string {
+	return "product_variants"
+}
+
+// PointsFor returns the variant's price, falling back to the product's
+func (v *ProductVariant) PointsFor(product *Product) int {
+	if v.PointsOverride != nil {
+		return *v.PointsOverride
+	}
+	return product.PointsRequired
+}
+
+// VariantLabel joins attribute values into a display label such as "M / 黑色"
+func VariantLabel(attributes []VariantAttribute) string {
+	values := make([]string, 0, len(attributes))
+	for _, attribute := range attributes {
+		values = append(values, attribute.Value)
+	}
+	return strings.Join(values, " / ")
+}
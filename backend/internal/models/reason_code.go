@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+)
+
+// ReasonCode is an admin-managed reason for granting or deducting points
+// Codes are stored upper case so "Q3_BONUS" and "q3_bonus" are the same reason.
+type ReasonCode struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	Code         string    `gorm:"size:50;not null;uniqueIndex" json:"code"`
+	LabelZh      string    `gorm:"size:100;not null" json:"label_zh"`
+	LabelEn      string    `gorm:"size:100;not null" json:"label_en"`
+	NoteRequired bool      `gorm:"default:false" json:"note_required"` // Transactions using the code must carry a free-text note
+	Status       string    `gorm:"type:enum('active','inactive');default:'active'" json:"status"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for ReasonCode model
+func (ReasonCode) TableName() string {
+	return "reason_codes"
+}
@@ -6,18 +6,25 @@ import (
 
 // RedemptionOrder represents a redemption order in the system
 type RedemptionOrder struct {
-	ID                 uint      `gorm:"primaryKey" json:"id"`
-	OrderNumber        string    `gorm:"uniqueIndex;size:50;not null" json:"order_number"`
-	UserID             uint      `gorm:"not null" json:"user_id"`
-	User               User      `gorm:"foreignKey:UserID" json:"user,omitempty"`
-	ProductID          uint      `gorm:"not null" json:"product_id"`
-	Product            Product   `gorm:"foreignKey:ProductID" json:"product,omitempty"`
-	ProductName        string    `gorm:"size:200;not null" json:"product_name"`
-	PointsCost         int       `gorm:"not null" json:"points_cost"`
-	PointsBalanceAfter int       `gorm:"not null" json:"points_balance_after"`
-	Status             string    `gorm:"type:enum('preparing','delivered');default:'preparing'" json:"status"`
-	CreatedAt          time.Time `json:"created_at"`
-	UpdatedAt          time.Time `json:"updated_at"`
+	ID                  uint            `gorm:"primaryKey" json:"id"`
+	OrderNumber         string          `gorm:"uniqueIndex;size:50;not null" json:"order_number"`
+	UserID              uint            `gorm:"not null" json:"user_id"`
+	User                User            `gorm:"foreignKey:UserID" json:"user,omitempty"`
+	ProductID           uint            `gorm:"not null" json:"product_id"`
+	Product             Product         `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	ProductName         string          `gorm:"size:200;not null" json:"product_name"`
+	VariantID           *uint           `gorm:"index" json:"variant_id"`
+	Variant             *ProductVariant `gorm:"foreignKey:VariantID" json:"variant,omitempty"`
+	VariantLabel        string          `gorm:"size:200" json:"variant_label"` // Snapshot of the variant label at redemption time
+	PointsCost          int             `gorm:"not null" json:"points_cost"`
+	PointsBalanceAfter  int             `gorm:"not null" json:"points_balance_after"`
+	OriginalPointsCost  int             `gorm:"not null;default:0" json:"original_points_cost"` // List price before campaign discount
+	CampaignID          *uint           `gorm:"index" json:"campaign_id"`
+	Campaign            *Campaign       `gorm:"foreignKey:CampaignID" json:"campaign,omitempty"`
+	CampaignBonusPoints int             `gorm:"default:0" json:"campaign_bonus_points"`
+	Status              string          `gorm:"type:enum('preparing','delivered');default:'preparing'" json:"status"`
+	CreatedAt           time.Time       `json:"created_at"`
+	UpdatedAt           time.Time       `json:"updated_at"`
 }
 
 // TableName specifies the table name for RedemptionOrder model
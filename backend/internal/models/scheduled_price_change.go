@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+)
+
+// Scheduled price change statuses
+const (
+	PriceChangePending   = "pending"   // Waiting for StartsAt
+	PriceChangeActive    = "active"    // Applied; a temporary change is waiting for EndsAt to revert
+	PriceChangeCompleted = "completed" // Applied, and reverted if temporary
+	PriceChangeCancelled = "cancelled"
+	PriceChangeMissed    = "missed" // The whole window passed before the scheduler could apply it
+)
+
+// ScheduledPriceChange sets a product's points price at a future time
+// A change with EndsAt is temporary: at EndsAt the price reverts to what it was when the
+// change was applied, unless an admin has changed the price again in the meantime.
+type ScheduledPriceChange struct {
+	ID             uint       `gorm:"primaryKey" json:"id"`
+	ProductID      uint       `gorm:"not null;index" json:"product_id"`
+	Product        Product    `gorm:"foreignKey:ProductID" json:"product,omitempty"`
+	PointsRequired int        `gorm:"not null" json:"points_required"`
+	StartsAt       time.Time  `gorm:"not null;index" json:"starts_at"`
+	EndsAt         *time.Time `gorm:"index" json:"ends_at"` // Nil for a permanent change
+	RevertPoints   *int       `json:"revert_points"`        // Price before the change, recorded when applied
+	Status         string     `gorm:"type:enum('pending','active','completed','cancelled','missed');default:'pending';index" json:"status"`
+	Note           string     `gorm:"size:500" json:"note"`
+	Error          string     `gorm:"size:500" json:"error"` // Last failure to apply or revert
+	OperatorID     uint       `gorm:"not null" json:"operator_id"`
+	Operator       User       `gorm:"foreignKey:OperatorID" json:"operator,omitempty"`
+	AppliedAt      *time.Time `json:"applied_at"`
+	RevertedAt     *time.Time `json:"reverted_at"`
+	CancelledAt    *time.Time `json:"cancelled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for ScheduledPriceChange model
+func (ScheduledPriceChange) TableName() string {
+	return "scheduled_price_changes"
+}
+
+// IsTemporary reports whether the change reverts at EndsAt
+func (c *ScheduledPriceChange) IsTemporary() bool {
+	return c.EndsAt != nil
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+)
+
+// Wallet types
+const (
+	WalletBenefit     = "benefit"     // Annual welfare points funded by the company
+	WalletRecognition = "recognition" // Peer recognition points
+)
+
+// WalletTypes lists every wallet type
+var WalletTypes = []string{WalletBenefit, WalletRecognition}
+
+// UserWallet holds a user's balance in one wallet
+// users.points_balance stays the total across all wallets.
+type UserWallet struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_user_wallet" json:"user_id"`
+	Wallet    string    `gorm:"type:enum('benefit','recognition');not null;uniqueIndex:idx_user_wallet" json:"wallet"`
+	Balance   int       `gorm:"not null;default:0" json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for UserWallet model
+func (UserWallet) TableName() string {
+	return "user_wallets"
+}
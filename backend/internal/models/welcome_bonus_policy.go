@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// WelcomeBonusPolicy holds the welcome bonus settings
+// There is a single policy row; it is read on every grant so changes apply without a redeploy
+type WelcomeBonusPolicy struct {
+	ID                 uint               `gorm:"primaryKey" json:"id"`
+	Enabled            bool               `gorm:"not null" json:"enabled"`
+	GrantAt            string             `gorm:"type:enum('creation','first_login');not null" json:"grant_at"`
+	DefaultAmount      int                `gorm:"not null" json:"default_amount"`
+	ProRateByJoinMonth bool               `gorm:"not null" json:"pro_rate_by_join_month"`
+	Rules              []WelcomeBonusRule `gorm:"foreignKey:PolicyID" json:"rules"`
+	UpdatedBy          *uint              `json:"updated_by"`
+	CreatedAt          time.Time          `json:"created_at"`
+	UpdatedAt          time.Time          `json:"updated_at"`
+}
+
+// TableName specifies the table name for WelcomeBonusPolicy model
+func (WelcomeBonusPolicy) TableName() string {
+	return "welcome_bonus_policies"
+}
+
+// WelcomeBonusRule overrides the default welcome bonus amount for a role and/or department
+// An empty Role or Department matches any value
+type WelcomeBonusRule struct {
+	ID         uint   `gorm:"primaryKey" json:"id"`
+	PolicyID   uint   `gorm:"not null;index" json:"policy_id"`
+	Role       string `gorm:"size:20" json:"role"`
+	Department string `gorm:"size:100" json:"department"`
+	Amount     int    `gorm:"not null" json:"amount"`
+}
+
+// TableName specifies the table name for WelcomeBonusRule model
+func (WelcomeBonusRule) TableName() string {
+	return "welcome_bonus_rules"
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CampaignRepository handles campaign data access operations
+type CampaignRepository struct {
+	db *gorm.DB
+}
+
+// NewCampaignRepository creates a new CampaignRepository instance
+func NewCampaignRepository(db *gorm.DB) *CampaignRepository {
+	return &CampaignRepository{db: db}
+}
+
+// Create creates a new campaign with its target products and categories
+func (r *CampaignRepository) Create(campaign *models.Campaign) error {
+	return r.db.Create(campaign).Error
+}
+
+// GetByID retrieves a campaign by ID with its target products and categories
+func (r *CampaignRepository) GetByID(id uint) (*models.Campaign, error) {
+	var campaign models.Campaign
+	err := r.db.Preload("Products").Preload("Categories").First(&campaign, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("campaign not found")
+		}
+		return nil, err
+	}
+	return &campaign, nil
+}
+
+// Update updates a campaign and replaces its target products and categories
+func (r *CampaignRepository) Update(campaign *models.Campaign) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Products", "Categories").Save(campaign).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(campaign).Association("Products").Replace(campaign.Products); err != nil {
+			return err
+		}
+		return tx.Model(campaign).Association("Categories").Replace(campaign.Categories)
+	})
+}
+
+// UpdateStatus updates a campaign's status (active/inactive)
+func (r *CampaignRepository) UpdateStatus(campaignID uint, status string) error {
+	if status != "active" && status != "inactive" {
+		return errors.New("invalid status: must be 'active' or 'inactive'")
+	}
+
+	return r.db.Model(&models.Campaign{}).
+		Where("id = ?", campaignID).
+		Update("status", status).Error
+}
+
+// List retrieves all campaigns with optional status filter (newest first)
+func (r *CampaignRepository) List(status *string) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+	query := r.db.Preload("Products").Preload("Categories")
+
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	err := query.Order("starts_at DESC").Find(&campaigns).Error
+	return campaigns, err
+}
+
+// GetActiveForProduct retrieves active campaigns whose time window contains at and that target
+// the product, directly or through one of categoryIDs (its category and that category's ancestors)
+func (r *CampaignRepository) GetActiveForProduct(productID uint, categoryIDs []uint, at time.Time) ([]models.Campaign, error) {
+	var campaigns []models.Campaign
+
+	targets := r.db.Where("applies_to_all = ?", true).
+		Or("id IN (?)", r.db.Table("campaign_products").Select("campaign_id").Where("product_id = ?", productID))
+	if len(categoryIDs) > 0 {
+		targets = targets.Or("id IN (?)", r.db.Table("campaign_categories").Select("campaign_id").Where("category_id IN ?", categoryIDs))
+	}
+
+	err := r.db.
+		Where("status = ? AND starts_at <= ? AND ends_at > ?", "active", at, at).
+		Where(targets).
+		Order("id ASC").
+		Find(&campaigns).Error
+	return campaigns, err
+}
+
+// CampaignEffectivenessStats holds redemption statistics for a campaign
+type CampaignEffectivenessStats struct {
+	CampaignID         uint      `json:"campaign_id"`
+	CampaignName       string    `json:"campaign_name"`
+	RuleType           string    `json:"rule_type"`
+	StartsAt           time.Time `json:"starts_at"`
+	EndsAt             time.Time `json:"ends_at"`
+	OrderCount         int       `json:"order_count"`
+	UniqueUsers        int       `json:"unique_users"`
+	PointsSpent        int       `json:"points_spent"`
+	DiscountGiven      int       `json:"discount_given"`
+	BonusPointsGranted int       `json:"bonus_points_granted"`
+}
+
+// GetEffectivenessStats aggregates the orders placed under each campaign
+func (r *CampaignRepository) GetEffectivenessStats() ([]CampaignEffectivenessStats, error) {
+	var stats []CampaignEffectivenessStats
+	err := r.db.Table("campaigns").
+		Select("campaigns.id as campaign_id, campaigns.name as campaign_name, campaigns.rule_type, campaigns.starts_at, campaigns.ends_at, " +
+			"COUNT(redemption_orders.id) as order_count, " +
+			"COUNT(DISTINCT redemption_orders.user_id) as unique_users, " +
+			"COALESCE(SUM(redemption_orders.points_cost), 0) as points_spent, " +
+			"COALESCE(SUM(redemption_orders.original_points_cost - redemption_orders.points_cost), 0) as discount_given, " +
+			"COALESCE(SUM(redemption_orders.campaign_bonus_points), 0) as bonus_points_granted").
+		Joins("LEFT JOIN redemption_orders ON redemption_orders.campaign_id = campaigns.id").
+		Group("campaigns.id, campaigns.name, campaigns.rule_type, campaigns.starts_at, campaigns.ends_at").
+		Order("campaigns.starts_at DESC").
+		Scan(&stats).Error
+	return stats, err
+}
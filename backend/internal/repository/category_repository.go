@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// CategoryRepository handles product category data access operations
+type CategoryRepository struct {
+	db *gorm.DB
+}
+
+// NewCategoryRepository creates a new CategoryRepository instance
+func NewCategoryRepository(db *gorm.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+// Create creates a new category
+func (r *CategoryRepository) Create(category *models.Category) error {
+	return r.db.Create(category).Error
+}
+
+// GetByID retrieves a category by ID
+func (r *CategoryRepository) GetByID(id uint) (*models.Category, error) {
+	var category models.Category
+	err := r.db.First(&category, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("category not found")
+		}
+		return nil, err
+	}
+	return &category, nil
+}
+
+// List retrieves all categories ordered by sort order and name
+func (r *CategoryRepository) List() ([]models.Category, error) {
+	var categories []models.Category
+	err := r.db.Order("sort_order ASC, name ASC").Find(&categories).Error
+	return categories, err
+}
+
+// Update saves a category
+func (r *CategoryRepository) Update(category *models.Category) error {
+	return r.db.Save(category).Error
+}
+
+// Delete deletes a category
+func (r *CategoryRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Category{}, id).Error
+}
+
+// ExistsByParentAndName checks if a sibling category already uses a name
+func (r *CategoryRepository) ExistsByParentAndName(parentID *uint, name string, excludeID uint) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.Category{}).Where("name = ? AND id <> ?", name, excludeID)
+
+	if parentID != nil {
+		query = query.Where("parent_id = ?", *parentID)
+	} else {
+		query = query.Where("parent_id IS NULL")
+	}
+
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// CountChildren counts a category's direct subcategories
+func (r *CategoryRepository) CountChildren(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Category{}).
+		Where("parent_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
+// CountCampaigns counts the campaigns that target a category
+func (r *CategoryRepository) CountCampaigns(id uint) (int64, error) {
+	var count int64
+	err := r.db.Table("campaign_categories").
+		Where("category_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
+
+// CountProducts counts the products assigned directly to a category
+func (r *CategoryRepository) CountProducts(id uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Product{}).
+		Where("category_id = ?", id).
+		Count(&count).Error
+	return count, err
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKeyRepository handles idempotency key data access operations
+type IdempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new IdempotencyKeyRepository instance
+func NewIdempotencyKeyRepository(db *gorm.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{db: db}
+}
+
+// Create creates a new idempotency key record
+// Fails if the user already holds the same key (unique index on user_id, key)
+func (r *IdempotencyKeyRepository) Create(record *models.IdempotencyKey) error {
+	return r.db.Create(record).Error
+}
+
+// GetByUserAndKey retrieves an idempotency key record for a user
+func (r *IdempotencyKeyRepository) GetByUserAndKey(userID uint, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.db.Where("user_id = ? AND `key` = ?", userID, key).First(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("idempotency key not found")
+		}
+		return nil, err
+	}
+	return &record, nil
+}
+
+// SaveResponse stores the response for an idempotency key
+func (r *IdempotencyKeyRepository) SaveResponse(id uint, status int, contentType, body string) error {
+	return r.db.Model(&models.IdempotencyKey{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"response_status":       status,
+			"response_content_type": contentType,
+			"response_body":         body,
+		}).Error
+}
+
+// Delete deletes an idempotency key record
+func (r *IdempotencyKeyRepository) Delete(id uint) error {
+	return r.db.Delete(&models.IdempotencyKey{}, id).Error
+}
+
+// DeleteExpired deletes all records that expired before the given time
+func (r *IdempotencyKeyRepository) DeleteExpired(before time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", before).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
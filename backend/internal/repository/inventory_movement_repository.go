@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// InventoryMovementRepository handles inventory movement data access operations
+type InventoryMovementRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryMovementRepository creates a new InventoryMovementRepository instance
+func NewInventoryMovementRepository(db *gorm.DB) *InventoryMovementRepository {
+	return &InventoryMovementRepository{db: db}
+}
+
+// Record saves a movement inside tx after its stock change has been applied
+// The resulting stock is read back from the product (and variant) rows in tx, so it always
+// matches what was written.
+func (r *InventoryMovementRepository) Record(tx *gorm.DB, movement *models.InventoryMovement) error {
+	err := tx.Model(&models.Product{}).
+		Select("stock_quantity").
+		Where("id = ?", movement.ProductID).
+		Scan(&movement.ProductStockAfter).Error
+	if err != nil {
+		return err
+	}
+
+	movement.StockAfter = movement.ProductStockAfter
+	if movement.VariantID != nil {
+		err = tx.Model(&models.ProductVariant{}).
+			Select("stock_quantity").
+			Where("id = ?", *movement.VariantID).
+			Scan(&movement.StockAfter).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Create(movement).Error
+}
+
+// SumQuantityByOrder totals the quantity of an order's movements of one type inside tx
+func (r *InventoryMovementRepository) SumQuantityByOrder(tx *gorm.DB, orderID uint, movementType string) (int, error) {
+	var total int
+	err := tx.Model(&models.InventoryMovement{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("related_order_id = ? AND movement_type = ?", orderID, movementType).
+		Scan(&total).Error
+	return total, err
+}
+
+// ListByProduct retrieves a product's movements with pagination (newest first), optionally for one variant
+func (r *InventoryMovementRepository) ListByProduct(productID uint, variantID *uint, page, pageSize int) ([]models.InventoryMovement, int64, error) {
+	var movements []models.InventoryMovement
+	var total int64
+
+	query := r.db.Model(&models.InventoryMovement{}).Where("product_id = ?", productID)
+	if variantID != nil {
+		query = query.Where("variant_id = ?", *variantID)
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err = query.Preload("Operator").Preload("RelatedOrder").
+		Order("created_at DESC").
+		Order("id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&movements).Error
+
+	return movements, total, err
+}
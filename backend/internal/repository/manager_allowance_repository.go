@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ManagerAllowanceRepository handles manager allowance data access operations
+type ManagerAllowanceRepository struct {
+	db *gorm.DB
+}
+
+// NewManagerAllowanceRepository creates a new ManagerAllowanceRepository instance
+func NewManagerAllowanceRepository(db *gorm.DB) *ManagerAllowanceRepository {
+	return &ManagerAllowanceRepository{db: db}
+}
+
+// Create creates a new manager allowance
+func (r *ManagerAllowanceRepository) Create(allowance *models.ManagerAllowance) error {
+	return r.db.Create(allowance).Error
+}
+
+// GetByID retrieves a manager allowance by ID
+func (r *ManagerAllowanceRepository) GetByID(id uint) (*models.ManagerAllowance, error) {
+	var allowance models.ManagerAllowance
+	err := r.db.Preload("Manager").First(&allowance, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("allowance not found")
+		}
+		return nil, err
+	}
+	return &allowance, nil
+}
+
+// List retrieves allowances, optionally for one manager (newest first)
+func (r *ManagerAllowanceRepository) List(managerID *uint) ([]models.ManagerAllowance, error) {
+	var allowances []models.ManagerAllowance
+	query := r.db.Preload("Manager")
+
+	if managerID != nil {
+		query = query.Where("manager_id = ?", *managerID)
+	}
+
+	err := query.Order("id DESC").Find(&allowances).Error
+	return allowances, err
+}
+
+// GetUsableForManagerWithLock retrieves a manager's active allowances valid at a time, with row locks
+// Allowances ending soonest come first so they are used up before they lapse
+func (r *ManagerAllowanceRepository) GetUsableForManagerWithLock(tx *gorm.DB, managerID uint, at time.Time) ([]models.ManagerAllowance, error) {
+	var allowances []models.ManagerAllowance
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("manager_id = ? AND status = ? AND starts_at <= ? AND ends_at > ? AND used < amount", managerID, "active", at, at).
+		Order("ends_at ASC, id ASC").
+		Find(&allowances).Error
+	return allowances, err
+}
+
+// AddUsed records points granted from an allowance inside tx
+func (r *ManagerAllowanceRepository) AddUsed(tx *gorm.DB, id uint, amount int) error {
+	return tx.Model(&models.ManagerAllowance{}).
+		Where("id = ?", id).
+		Update("used", gorm.Expr("used + ?", amount)).Error
+}
+
+// UpdateStatus updates an allowance's status
+func (r *ManagerAllowanceRepository) UpdateStatus(id uint, status string) error {
+	return r.db.Model(&models.ManagerAllowance{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationRepository handles notification data access operations
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationRepository creates a new NotificationRepository instance
+func NewNotificationRepository(db *gorm.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// CreateBatch creates notifications for several users at once
+func (r *NotificationRepository) CreateBatch(notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.db.Create(&notifications).Error
+}
+
+// ListByUser retrieves a user's notifications with pagination (newest first), optionally only unread ones
+func (r *NotificationRepository) ListByUser(userID uint, unreadOnly bool, page, pageSize int) ([]models.Notification, int64, error) {
+	var notifications []models.Notification
+	var total int64
+
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	err := query.Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err = query.Order("created_at DESC").
+		Order("id DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&notifications).Error
+
+	return notifications, total, err
+}
+
+// CountUnread counts a user's unread notifications
+func (r *NotificationRepository) CountUnread(userID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead marks one of a user's notifications as read
+func (r *NotificationRepository) MarkRead(userID, notificationID uint, at time.Time) error {
+	result := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Where("read_at IS NULL").
+		Update("read_at", at)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		var count int64
+		err := r.db.Model(&models.Notification{}).
+			Where("id = ? AND user_id = ?", notificationID, userID).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("notification not found")
+		}
+	}
+
+	return nil
+}
+
+// MarkAllRead marks all of a user's notifications as read, returning how many were unread
+func (r *NotificationRepository) MarkAllRead(userID uint, at time.Time) (int64, error) {
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", at)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PointsGrantApprovalRepository handles points grant approval data access operations
+type PointsGrantApprovalRepository struct {
+	db *gorm.DB
+}
+
+// NewPointsGrantApprovalRepository creates a new PointsGrantApprovalRepository instance
+func NewPointsGrantApprovalRepository(db *gorm.DB) *PointsGrantApprovalRepository {
+	return &PointsGrantApprovalRepository{db: db}
+}
+
+// Create creates a new grant approval
+func (r *PointsGrantApprovalRepository) Create(approval *models.PointsGrantApproval) error {
+	return r.db.Create(approval).Error
+}
+
+// GetByID retrieves a grant approval by ID
+func (r *PointsGrantApprovalRepository) GetByID(id uint) (*models.PointsGrantApproval, error) {
+	var approval models.PointsGrantApproval
+	err := r.db.Preload("User").Preload("Requester").Preload("Reviewer").Preload("ReasonCode").First(&approval, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("grant approval not found")
+		}
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// GetByIDWithLock retrieves a grant approval by ID with row lock (for transaction)
+func (r *PointsGrantApprovalRepository) GetByIDWithLock(tx *gorm.DB, id uint) (*models.PointsGrantApproval, error) {
+	var approval models.PointsGrantApproval
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&approval, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("grant approval not found")
+		}
+		return nil, err
+	}
+	return &approval, nil
+}
+
+// List retrieves grant approvals with optional status filter (newest first)
+func (r *PointsGrantApprovalRepository) List(status *string) ([]models.PointsGrantApproval, error) {
+	var approvals []models.PointsGrantApproval
+	query := r.db.Preload("User").Preload("Requester").Preload("Reviewer").Preload("ReasonCode")
+
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	err := query.Order("id DESC").Find(&approvals).Error
+	return approvals, err
+}
+
+// UpdateReview records the review outcome of a grant approval inside tx
+func (r *PointsGrantApprovalRepository) UpdateReview(tx *gorm.DB, approval *models.PointsGrantApproval) error {
+	return tx.Model(approval).Select("Status", "ReviewedBy", "ReviewNote", "ReviewedAt", "TransactionID").Updates(approval).Error
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrPointsStatementNotFound is returned when no statement has been stored for a user and period
+var ErrPointsStatementNotFound = errors.New("points statement not found")
+
+// PointsStatementRepository handles points statement data access operations
+// Statements are immutable, so there are no update or delete methods.
+type PointsStatementRepository struct {
+	db *gorm.DB
+}
+
+// NewPointsStatementRepository creates a new PointsStatementRepository instance
+func NewPointsStatementRepository(db *gorm.DB) *PointsStatementRepository {
+	return &PointsStatementRepository{db: db}
+}
+
+// Create stores a new points statement
+func (r *PointsStatementRepository) Create(statement *models.PointsStatement) error {
+	return r.db.Create(statement).Error
+}
+
+// GetByUserAndPeriod retrieves a user's statement for a period (YYYY-MM)
+func (r *PointsStatementRepository) GetByUserAndPeriod(userID uint, period string) (*models.PointsStatement, error) {
+	var statement models.PointsStatement
+	err := r.db.Where("user_id = ? AND period = ?", userID, period).First(&statement).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPointsStatementNotFound
+		}
+		return nil, err
+	}
+	return &statement, nil
+}
+
+// ListByUserID retrieves all stored statements for a user (newest period first)
+func (r *PointsStatementRepository) ListByUserID(userID uint) ([]models.PointsStatement, error) {
+	var statements []models.PointsStatement
+	err := r.db.Where("user_id = ?", userID).
+		Order("period DESC").
+		Find(&statements).Error
+	return statements, err
+}
+
+// GetUserIDsWithPeriod retrieves the IDs of users who already have a statement for a period
+func (r *PointsStatementRepository) GetUserIDsWithPeriod(period string) ([]uint, error) {
+	var userIDs []uint
+	err := r.db.Model(&models.PointsStatement{}).
+		Where("period = ?", period).
+		Pluck("user_id", &userIDs).Error
+	return userIDs, err
+}
@@ -3,8 +3,10 @@ package repository
 import (
 	"awsome-shop/internal/models"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // PointsTransactionRepository handles points transaction data access operations
@@ -71,6 +73,69 @@ func (r *PointsTransactionRepository) GetByUserIDWithPagination(userID uint, pag
 	return transactions, total, err
 }
 
+// PointsTransactionFilter holds the filters and keyset cursor for ledger queries
+// Nil or empty fields are not applied
+type PointsTransactionFilter struct {
+	UserID         *uint
+	Types          []string
+	Wallet         string
+	From           *time.Time // Inclusive lower bound on created_at
+	To             *time.Time // Exclusive upper bound on created_at
+	MinAmount      *int       // Inclusive lower bound on the absolute amount
+	MaxAmount      *int       // Inclusive upper bound on the absolute amount
+	ReasonContains string
+	BeforeID       uint // Keyset cursor: only entries with a smaller ID are returned
+	Limit          int
+}
+
+// ListWithFilter retrieves transactions newest first using keyset pagination on ID
+// Unlike OFFSET pagination, the cost of a page does not grow with its depth
+func (r *PointsTransactionRepository) ListWithFilter(filter PointsTransactionFilter) ([]models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	query := r.db.Preload("Operator").Preload("RelatedOrder").Preload("ReasonCode")
+
+	if filter.UserID != nil {
+		query = query.Where("user_id = ?", *filter.UserID)
+	} else {
+		query = query.Preload("User")
+	}
+
+	if len(filter.Types) > 0 {
+		query = query.Where("transaction_type IN ?", filter.Types)
+	}
+
+	if filter.Wallet != "" {
+		query = query.Where("wallet = ?", filter.Wallet)
+	}
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if filter.MinAmount != nil {
+		query = query.Where("ABS(amount) >= ?", *filter.MinAmount)
+	}
+
+	if filter.MaxAmount != nil {
+		query = query.Where("ABS(amount) <= ?", *filter.MaxAmount)
+	}
+
+	if filter.ReasonContains != "" {
+		query = query.Where("reason LIKE ?", "%"+escapeLike(filter.ReasonContains)+"%")
+	}
+
+	if filter.BeforeID > 0 {
+		query = query.Where("id < ?", filter.BeforeID)
+	}
+
+	err := query.Order("id DESC").Limit(filter.Limit).Find(&transactions).Error
+	return transactions, err
+}
+
 // List retrieves all transactions with optional filters
 func (r *PointsTransactionRepository) List(userID *uint, transactionType *string) ([]models.PointsTransaction, error) {
 	var transactions []models.PointsTransaction
@@ -89,23 +154,63 @@ func (r *PointsTransactionRepository) List(userID *uint, transactionType *string
 }
 
 // GetGrantTransactions retrieves all grant transactions for reports
+// When netReversals is set, grants that have been reversed are left out
 type GrantTransactionStats struct {
 	UserName     string
 	UserEmail    string
 	Amount       int
 	Reason       string
+	ReasonCode   string
 	OperatorName string
+	Reversed     bool
 	CreatedAt    string
 }
 
-func (r *PointsTransactionRepository) GetGrantTransactions() ([]GrantTransactionStats, error) {
+func (r *PointsTransactionRepository) GetGrantTransactions(netReversals bool) ([]GrantTransactionStats, error) {
 	var stats []GrantTransactionStats
-	err := r.db.Table("points_transactions").
-		Select("users.full_name as user_name, users.email as user_email, points_transactions.amount, points_transactions.reason, operators.full_name as operator_name, points_transactions.created_at").
+	query := r.db.Table("points_transactions").
+		Select("users.full_name as user_name, users.email as user_email, points_transactions.amount, points_transactions.reason, COALESCE(reason_codes.code, '') as reason_code, operators.full_name as operator_name, reversals.id IS NOT NULL as reversed, points_transactions.created_at").
 		Joins("LEFT JOIN users ON users.id = points_transactions.user_id").
 		Joins("LEFT JOIN users as operators ON operators.id = points_transactions.operator_id").
-		Where("points_transactions.transaction_type = ?", "grant").
-		Order("points_transactions.created_at DESC").
+		Joins("LEFT JOIN reason_codes ON reason_codes.id = points_transactions.reason_code_id").
+		Joins("LEFT JOIN points_transactions as reversals ON reversals.reversal_of_id = points_transactions.id").
+		Where("points_transactions.transaction_type = ?", "grant")
+
+	if netReversals {
+		query = query.Where("reversals.id IS NULL")
+	}
+
+	err := query.Order("points_transactions.created_at DESC").
+		Scan(&stats).Error
+	return stats, err
+}
+
+// GetGrantTotalsByReasonCode aggregates grant transactions by reason code for reports
+// Grants without a code are grouped under an empty ReasonCode. When netReversals is set,
+// grants that have been reversed are left out.
+type GrantReasonCodeStats struct {
+	ReasonCode  string
+	LabelZh     string
+	LabelEn     string
+	GrantCount  int
+	UserCount   int
+	TotalAmount int
+}
+
+func (r *PointsTransactionRepository) GetGrantTotalsByReasonCode(netReversals bool) ([]GrantReasonCodeStats, error) {
+	var stats []GrantReasonCodeStats
+	query := r.db.Table("points_transactions").
+		Select("COALESCE(reason_codes.code, '') as reason_code, COALESCE(reason_codes.label_zh, '') as label_zh, COALESCE(reason_codes.label_en, '') as label_en, COUNT(*) as grant_count, COUNT(DISTINCT points_transactions.user_id) as user_count, SUM(points_transactions.amount) as total_amount").
+		Joins("LEFT JOIN reason_codes ON reason_codes.id = points_transactions.reason_code_id").
+		Joins("LEFT JOIN points_transactions as reversals ON reversals.reversal_of_id = points_transactions.id").
+		Where("points_transactions.transaction_type = ?", "grant")
+
+	if netReversals {
+		query = query.Where("reversals.id IS NULL")
+	}
+
+	err := query.Group("reason_codes.code, reason_codes.label_zh, reason_codes.label_en").
+		Order("total_amount DESC").
 		Scan(&stats).Error
 	return stats, err
 }
@@ -156,9 +261,17 @@ func (r *PointsTransactionRepository) GetTotalPointsRedeemed() (int, error) {
 	return total, err
 }
 
-// BatchCreate creates multiple transactions in a single operation
+// BatchCreate creates multiple transactions in a single database transaction
+// Entries are inserted one at a time so each links to the previous entry in its user's hash chain.
 func (r *PointsTransactionRepository) BatchCreate(transactions []models.PointsTransaction) error {
-	return r.db.Create(&transactions).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i := range transactions {
+			if err := tx.Create(&transactions[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // GetByUserIDAndType retrieves transactions for a user filtered by type
@@ -170,3 +283,114 @@ func (r *PointsTransactionRepository) GetByUserIDAndType(userID uint, transactio
 		Find(&transactions).Error
 	return transactions, err
 }
+
+// GetByUserIDInLedgerOrder retrieves all transactions for a user in the order they were written
+func (r *PointsTransactionRepository) GetByUserIDInLedgerOrder(userID uint) ([]models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Where("user_id = ?", userID).
+		Order("id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// SumAmountByWallet sums a user's transaction amounts per wallet (use tx to read under a lock)
+func (r *PointsTransactionRepository) SumAmountByWallet(tx *gorm.DB, userID uint) (map[string]int, error) {
+	var rows []struct {
+		Wallet string
+		Total  int
+	}
+	err := tx.Model(&models.PointsTransaction{}).
+		Select("wallet, COALESCE(SUM(amount), 0) as total").
+		Where("user_id = ?", userID).
+		Group("wallet").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]int, len(rows))
+	for _, row := range rows {
+		totals[row.Wallet] = row.Total
+	}
+	return totals, nil
+}
+
+// GetByUserIDInRange retrieves a user's transactions created in [from, to) in ledger order
+func (r *PointsTransactionRepository) GetByUserIDInRange(userID uint, from, to time.Time) ([]models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Where("user_id = ? AND created_at >= ? AND created_at < ?", userID, from, to).
+		Order("id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// GetLastByUserIDBefore retrieves a user's last transaction created before a time, or nil if there is none
+func (r *PointsTransactionRepository) GetLastByUserIDBefore(userID uint, before time.Time) (*models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Where("user_id = ? AND created_at < ?", userID, before).
+		Order("id DESC").
+		Limit(1).
+		Find(&transactions).Error
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+	return &transactions[0], nil
+}
+
+// SumAmountByUserIDBefore sums a user's transaction amounts created before a time
+func (r *PointsTransactionRepository) SumAmountByUserIDBefore(userID uint, before time.Time) (int, error) {
+	var total int
+	err := r.db.Model(&models.PointsTransaction{}).
+		Where("user_id = ? AND created_at < ?", userID, before).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// GetByIDWithLock retrieves a points transaction by ID with row lock (for transaction)
+func (r *PointsTransactionRepository) GetByIDWithLock(tx *gorm.DB, id uint) (*models.PointsTransaction, error) {
+	var transaction models.PointsTransaction
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&transaction, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("transaction not found")
+		}
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// ExistsReversalOf checks if a transaction has already been reversed (use tx to read under a lock)
+func (r *PointsTransactionRepository) ExistsReversalOf(tx *gorm.DB, transactionID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&models.PointsTransaction{}).
+		Where("reversal_of_id = ?", transactionID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetOperatorGrantsInRange retrieves grants made by an operator in [from, to), oldest first
+// System grants such as welcome and campaign bonuses have no operator and are left out.
+func (r *PointsTransactionRepository) GetOperatorGrantsInRange(from, to time.Time) ([]models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Preload("User").Preload("Operator").
+		Where("transaction_type = ? AND operator_id IS NOT NULL", "grant").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// GetFirstRedemptionInRange retrieves a user's first redemption in [from, to), or nil if there is none
+func (r *PointsTransactionRepository) GetFirstRedemptionInRange(userID uint, from, to time.Time) (*models.PointsTransaction, error) {
+	var transactions []models.PointsTransaction
+	err := r.db.Where("user_id = ? AND transaction_type = ?", userID, "redemption").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("id ASC").
+		Limit(1).
+		Find(&transactions).Error
+	if err != nil || len(transactions) == 0 {
+		return nil, err
+	}
+	return &transactions[0], nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ProductImageRepository handles product image data access operations
+type ProductImageRepository struct {
+	db *gorm.DB
+}
+
+// NewProductImageRepository creates a new ProductImageRepository instance
+func NewProductImageRepository(db *gorm.DB) *ProductImageRepository {
+	return &ProductImageRepository{db: db}
+}
+
+// Create creates a new product image inside tx
+func (r *ProductImageRepository) Create(tx *gorm.DB, image *models.ProductImage) error {
+	return tx.Create(image).Error
+}
+
+// GetByID retrieves a product image by ID
+func (r *ProductImageRepository) GetByID(id uint) (*models.ProductImage, error) {
+	var image models.ProductImage
+	err := r.db.First(&image, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("image not found")
+		}
+		return nil, err
+	}
+	return &image, nil
+}
+
+// ListByProduct retrieves a product's images in display order inside tx
+func (r *ProductImageRepository) ListByProduct(tx *gorm.DB, productID uint) ([]models.ProductImage, error) {
+	var images []models.ProductImage
+	err := tx.Where("product_id = ?", productID).
+		Order("sort_order ASC, id ASC").
+		Find(&images).Error
+	return images, err
+}
+
+// UpdateSortOrder sets an image's position inside tx
+func (r *ProductImageRepository) UpdateSortOrder(tx *gorm.DB, id uint, sortOrder int) error {
+	return tx.Model(&models.ProductImage{}).
+		Where("id = ?", id).
+		Update("sort_order", sortOrder).Error
+}
+
+// Delete deletes a product image inside tx
+func (r *ProductImageRepository) Delete(tx *gorm.DB, id uint) error {
+	return tx.Delete(&models.ProductImage{}, id).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductPriceHistoryRepository handles product price history data access operations
+type ProductPriceHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewProductPriceHistoryRepository creates a new ProductPriceHistoryRepository instance
+func NewProductPriceHistoryRepository(db *gorm.DB) *ProductPriceHistoryRepository {
+	return &ProductPriceHistoryRepository{db: db}
+}
+
+// ListByProduct retrieves a product's price changes with their operators, newest first
+func (r *ProductPriceHistoryRepository) ListByProduct(productID uint) ([]models.ProductPriceHistory, error) {
+	var history []models.ProductPriceHistory
+	err := r.db.Preload("Operator").
+		Where("product_id = ?", productID).
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&history).Error
+	return history, err
+}
+
+// ListInRange retrieves price changes made in [from, to) across all products, oldest first
+func (r *ProductPriceHistoryRepository) ListInRange(from, to time.Time) ([]models.ProductPriceHistory, error) {
+	var history []models.ProductPriceHistory
+	err := r.db.Preload("Product").Preload("Operator").
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at ASC").
+		Order("id ASC").
+		Find(&history).Error
+	return history, err
+}
+
+// GetEarliestSince retrieves each product's first price change at or after since, keyed by product ID
+// Products without a change in that period are absent from the map.
+func (r *ProductPriceHistoryRepository) GetEarliestSince(productIDs []uint, since time.Time) (map[uint]models.ProductPriceHistory, error) {
+	earliest := make(map[uint]models.ProductPriceHistory)
+	if len(productIDs) == 0 {
+		return earliest, nil
+	}
+
+	firstIDs := r.db.Model(&models.ProductPriceHistory{}).
+		Select("MIN(id)").
+		Where("product_id IN ? AND created_at >= ?", productIDs, since).
+		Group("product_id")
+
+	var history []models.ProductPriceHistory
+	err := r.db.Where("id IN (?)", firstIDs).Find(&history).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, change := range history {
+		earliest[change.ProductID] = change
+	}
+	return earliest, nil
+}
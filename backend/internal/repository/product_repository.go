@@ -3,8 +3,10 @@ package repository
 import (
 	"awsome-shop/internal/models"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProductRepository handles product data access operations
@@ -17,6 +19,11 @@ func NewProductRepository(db *gorm.DB) *ProductRepository {
 	return &ProductRepository{db: db}
 }
 
+// orderBySortOrder sorts preloaded variants and images for display
+func orderBySortOrder(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
+
 // Create creates a new product
 func (r *ProductRepository) Create(product *models.Product) error {
 	return r.db.Create(product).Error
@@ -25,7 +32,7 @@ func (r *ProductRepository) Create(product *models.Product) error {
 // GetByID retrieves a product by ID
 func (r *ProductRepository) GetByID(id uint) (*models.Product, error) {
 	var product models.Product
-	err := r.db.First(&product, id).Error
+	err := r.db.Preload("Category").Preload("Tags").Preload("Variants", orderBySortOrder).Preload("Images", orderBySortOrder).First(&product, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("product not found")
@@ -45,17 +52,37 @@ func (r *ProductRepository) Delete(id uint) error {
 	return r.db.Delete(&models.Product{}, id).Error
 }
 
-// GetActiveProducts retrieves all active (上架) products
-func (r *ProductRepository) GetActiveProducts() ([]models.Product, error) {
-	var products []models.Product
-	err := r.db.Where("status = ?", "active").Find(&products).Error
-	return products, err
+// GetByIDs retrieves products by ID, returned in the order of ids
+// IDs that no longer exist are skipped.
+func (r *ProductRepository) GetByIDs(ids []uint) ([]models.Product, error) {
+	if len(ids) == 0 {
+		return []models.Product{}, nil
+	}
+
+	var found []models.Product
+	err := r.db.Preload("Category").Preload("Tags").Preload("Variants", orderBySortOrder).Preload("Images", orderBySortOrder).Where("id IN ?", ids).Find(&found).Error
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]models.Product, len(found))
+	for _, product := range found {
+		byID[product.ID] = product
+	}
+
+	products := make([]models.Product, 0, len(ids))
+	for _, id := range ids {
+		if product, ok := byID[id]; ok {
+			products = append(products, product)
+		}
+	}
+	return products, nil
 }
 
 // List retrieves all products with optional status filter
 func (r *ProductRepository) List(status *string) ([]models.Product, error) {
 	var products []models.Product
-	query := r.db
+	query := r.db.Preload("Category").Preload("Tags").Preload("Variants", orderBySortOrder).Preload("Images", orderBySortOrder)
 
 	if status != nil {
 		query = query.Where("status = ?", *status)
@@ -65,19 +92,22 @@ func (r *ProductRepository) List(status *string) ([]models.Product, error) {
 	return products, err
 }
 
-// UpdateStock updates a product's stock quantity
-func (r *ProductRepository) UpdateStock(productID uint, newStock int) error {
-	return r.db.Model(&models.Product{}).
-		Where("id = ?", productID).
-		Update("stock_quantity", newStock).Error
+// ListLowStock retrieves active products at or below their low-stock threshold, lowest stock first
+func (r *ProductRepository) ListLowStock() ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Preload("Category").Preload("Tags").Preload("Variants", orderBySortOrder).Preload("Images", orderBySortOrder).
+		Where("status = ? AND stock_quantity <= low_stock_threshold", "active").
+		Order("stock_quantity ASC, id ASC").
+		Find(&products).Error
+	return products, err
 }
 
-// DecrementStock decrements a product's stock by the specified amount
-// Returns error if stock is insufficient
-func (r *ProductRepository) DecrementStock(productID uint, amount int) error {
-	result := r.db.Model(&models.Product{}).
-		Where("id = ? AND stock_quantity >= ?", productID, amount).
-		Update("stock_quantity", gorm.Expr("stock_quantity - ?", amount))
+// AdjustStock changes a product's stock by delta inside tx
+// Returns error if stock would become negative. Callers record the change as an inventory movement.
+func (r *ProductRepository) AdjustStock(tx *gorm.DB, productID uint, delta int) error {
+	result := tx.Model(&models.Product{}).
+		Where("id = ? AND stock_quantity + ? >= 0", productID, delta).
+		Update("stock_quantity", gorm.Expr("stock_quantity + ?", delta))
 
 	if result.Error != nil {
 		return result.Error
@@ -91,14 +121,22 @@ func (r *ProductRepository) DecrementStock(productID uint, amount int) error {
 }
 
 // UpdateStatus updates a product's status (active/inactive)
+// Deactivations are stamped so the availability job does not re-activate the product.
 func (r *ProductRepository) UpdateStatus(productID uint, status string) error {
 	if status != "active" && status != "inactive" {
 		return errors.New("invalid status: must be 'active' or 'inactive'")
 	}
 
+	updates := map[string]interface{}{
+		"status": status,
+	}
+	if status == "inactive" {
+		updates["deactivated_at"] = time.Now()
+	}
+
 	return r.db.Model(&models.Product{}).
 		Where("id = ?", productID).
-		Update("status", status).Error
+		Updates(updates).Error
 }
 
 // UpdatePoints updates a product's required points
@@ -111,7 +149,7 @@ func (r *ProductRepository) UpdatePoints(productID uint, points int) error {
 // GetByIDWithLock retrieves a product by ID with row lock (for transaction)
 func (r *ProductRepository) GetByIDWithLock(tx *gorm.DB, id uint) (*models.Product, error) {
 	var product models.Product
-	err := tx.Clauses(gorm.Locking{Strength: "UPDATE"}).First(&product, id).Error
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errors.New("product not found")
@@ -121,6 +159,90 @@ func (r *ProductRepository) GetByIDWithLock(tx *gorm.DB, id uint) (*models.Produ
 	return &product, nil
 }
 
+// GetDetailByIDWithLock locks a product row inside tx and loads the same associations as GetByID
+func (r *ProductRepository) GetDetailByIDWithLock(tx *gorm.DB, id uint) (*models.Product, error) {
+	var product models.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Category").
+		Preload("Tags").
+		Preload("Variants", orderBySortOrder).
+		Preload("Images", orderBySortOrder).
+		First(&product, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("product not found")
+		}
+		return nil, err
+	}
+	return &product, nil
+}
+
+// UpdateAvailability saves a product's availability window along with the status and
+// published_at the window implies; a nil bound removes it
+func (r *ProductRepository) UpdateAvailability(product *models.Product) error {
+	return r.db.Model(&models.Product{}).
+		Where("id = ?", product.ID).
+		Updates(map[string]interface{}{
+			"available_from":  product.AvailableFrom,
+			"available_until": product.AvailableUntil,
+			"status":          product.Status,
+			"published_at":    product.PublishedAt,
+		}).Error
+}
+
+// ListDueToPublish retrieves inactive products whose availability window has opened since the
+// availability job last published them and since an admin last deactivated them
+func (r *ProductRepository) ListDueToPublish(now time.Time) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Where("status = ? AND available_from <= ?", "inactive", now).
+		Where("available_until IS NULL OR available_until > ?", now).
+		Where("published_at IS NULL OR published_at < available_from").
+		Where("deactivated_at IS NULL OR deactivated_at < available_from").
+		Find(&products).Error
+	return products, err
+}
+
+// ListDueToUnpublish retrieves active products whose availability window has closed since the
+// availability job last unpublished them
+func (r *ProductRepository) ListDueToUnpublish(now time.Time) ([]models.Product, error) {
+	var products []models.Product
+	err := r.db.Where("status = ? AND available_until <= ?", "active", now).
+		Where("unpublished_at IS NULL OR unpublished_at < available_until").
+		Find(&products).Error
+	return products, err
+}
+
+// Publish activates an inactive product for its availability window
+// It reports false if the product is no longer inactive or an admin has since deactivated it.
+func (r *ProductRepository) Publish(productID uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Product{}).
+		Where("id = ? AND status = ?", productID, "inactive").
+		Where("deactivated_at IS NULL OR deactivated_at < available_from").
+		Updates(map[string]interface{}{
+			"status":       "active",
+			"published_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Unpublish deactivates an active product whose availability window has closed
+// It reports false if the product is no longer active.
+func (r *ProductRepository) Unpublish(productID uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Product{}).
+		Where("id = ? AND status = ?", productID, "active").
+		Updates(map[string]interface{}{
+			"status":         "inactive",
+			"unpublished_at": at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // BatchCreate creates multiple products in a single transaction
 func (r *ProductRepository) BatchCreate(products []models.Product) error {
 	return r.db.Create(&products).Error
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// Catalog sort orders
+const (
+	ProductSortRelevance  = "relevance" // Best keyword match first; only meaningful with a keyword
+	ProductSortNewest     = "newest"
+	ProductSortPriceAsc   = "price_asc"
+	ProductSortPriceDesc  = "price_desc"
+	ProductSortPopularity = "popularity" // Most redeemed first
+)
+
+// ProductSortOrders lists every catalog sort order
+var ProductSortOrders = []string{
+	ProductSortRelevance,
+	ProductSortNewest,
+	ProductSortPriceAsc,
+	ProductSortPriceDesc,
+	ProductSortPopularity,
+}
+
+// ProductSearchQuery describes a search over active catalog products
+// Nil or empty filters are not applied.
+type ProductSearchQuery struct {
+	Keyword     string   // Matched against localized names and descriptions
+	CategoryIDs []uint   // Matches products in any of these categories
+	Tags        []string // Matches products carrying every one of these tags
+	MinPoints   *int     // Inclusive lower bound on PointsRequired
+	MaxPoints   *int     // Inclusive upper bound on PointsRequired
+	InStockOnly bool
+	AvailableAt time.Time // Only matches products whose availability window includes this time; zero skips the check
+	Sort        string    // One of ProductSortOrders
+	Page        int       // 1-based
+	PageSize    int
+}
+
+// ProductSearchResult is one page of matching product IDs in result order
+type ProductSearchResult struct {
+	ProductIDs []uint
+	Total      int64
+}
+
+// ProductSearcher finds catalog products
+// The MySQL implementation queries the products table directly; another engine can be plugged
+// in by implementing this interface and returning the matching IDs.
+type ProductSearcher interface {
+	Search(query ProductSearchQuery) (*ProductSearchResult, error)
+}
+
+// productSearchColumns are the columns covered by the idx_products_search FULLTEXT index
+const productSearchColumns = "name_zh, name_en, short_description_zh, short_description_en, description_zh, description_en"
+
+// minFullTextTermLength is MySQL's default ngram_token_size; shorter terms cannot match the index
+const minFullTextTermLength = 2
+
+// MySQLProductSearcher searches products with a MySQL FULLTEXT index using the ngram parser,
+// which tokenizes Chinese text that has no spaces between words
+type MySQLProductSearcher struct {
+	db *gorm.DB
+}
+
+// NewMySQLProductSearcher creates a new MySQLProductSearcher instance
+func NewMySQLProductSearcher(db *gorm.DB) *MySQLProductSearcher {
+	return &MySQLProductSearcher{db: db}
+}
+
+// fullTextQuery builds a boolean-mode query requiring every term as a phrase
+// Terms too short for the ngram index are returned separately to be matched with LIKE.
+func fullTextQuery(keyword string) (string, []string) {
+	var required []string
+	var short []string
+
+	for _, term := range strings.Fields(keyword) {
+		term = strings.ReplaceAll(term, `"`, "")
+		if term == "" {
+			continue
+		}
+		if utf8.RuneCountInString(term) < minFullTextTermLength {
+			short = append(short, term)
+			continue
+		}
+		required = append(required, `+"`+term+`"`)
+	}
+
+	return strings.Join(required, " "), short
+}
+
+// Search returns one page of active product IDs matching the query
+func (s *MySQLProductSearcher) Search(query ProductSearchQuery) (*ProductSearchResult, error) {
+	filtered := s.db.Table("products").Where("products.status = ?", "active")
+
+	var matchArgs []interface{}
+	if query.Keyword != "" {
+		against, shortTerms := fullTextQuery(query.Keyword)
+		if against != "" {
+			matchArgs = []interface{}{against}
+			filtered = filtered.Where("MATCH("+productSearchColumns+") AGAINST (? IN BOOLEAN MODE)", against)
+		}
+		for _, term := range shortTerms {
+			pattern := "%" + escapeLike(term) + "%"
+			filtered = filtered.Where("(products.name_zh LIKE ? OR products.name_en LIKE ?)", pattern, pattern)
+		}
+	}
+
+	if len(query.CategoryIDs) > 0 {
+		filtered = filtered.Where("products.category_id IN ?", query.CategoryIDs)
+	}
+
+	if len(query.Tags) > 0 {
+		tagged := s.db.Table("product_tags").
+			Select("product_tags.product_id").
+			Joins("JOIN tags ON tags.id = product_tags.tag_id").
+			Where("tags.name IN ?", query.Tags).
+			Group("product_tags.product_id").
+			Having("COUNT(DISTINCT tags.id) = ?", len(query.Tags))
+		filtered = filtered.Where("products.id IN (?)", tagged)
+	}
+
+	if query.MinPoints != nil {
+		filtered = filtered.Where("products.points_required >= ?", *query.MinPoints)
+	}
+	if query.MaxPoints != nil {
+		filtered = filtered.Where("products.points_required <= ?", *query.MaxPoints)
+	}
+	if query.InStockOnly {
+		filtered = filtered.Where("products.stock_quantity > 0")
+	}
+	if !query.AvailableAt.IsZero() {
+		filtered = filtered.
+			Where("products.available_from IS NULL OR products.available_from <= ?", query.AvailableAt).
+			Where("products.available_until IS NULL OR products.available_until > ?", query.AvailableAt)
+	}
+
+	result := &ProductSearchResult{}
+	if err := filtered.Session(&gorm.Session{}).Count(&result.Total).Error; err != nil {
+		return nil, err
+	}
+
+	ordered := filtered.Select("products.id")
+	switch query.Sort {
+	case ProductSortRelevance:
+		if matchArgs != nil {
+			ordered = ordered.Order(gorm.Expr("MATCH("+productSearchColumns+") AGAINST (? IN BOOLEAN MODE) DESC", matchArgs...))
+		}
+	case ProductSortPriceAsc:
+		ordered = ordered.Order("products.points_required ASC")
+	case ProductSortPriceDesc:
+		ordered = ordered.Order("products.points_required DESC")
+	case ProductSortPopularity:
+		ordered = ordered.Order("(SELECT COUNT(*) FROM redemption_orders WHERE redemption_orders.product_id = products.id) DESC")
+	}
+	// Newest is also the tie-breaker, so pages are stable for every sort order
+	ordered = ordered.Order("products.created_at DESC").Order("products.id DESC")
+
+	err := ordered.
+		Limit(query.PageSize).
+		Offset((query.Page-1)*query.PageSize).
+		Pluck("products.id", &result.ProductIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ProductVariantRepository handles product variant data access operations
+type ProductVariantRepository struct {
+	db *gorm.DB
+}
+
+// NewProductVariantRepository creates a new ProductVariantRepository instance
+func NewProductVariantRepository(db *gorm.DB) *ProductVariantRepository {
+	return &ProductVariantRepository{db: db}
+}
+
+// Create creates a new variant inside tx
+func (r *ProductVariantRepository) Create(tx *gorm.DB, variant *models.ProductVariant) error {
+	return tx.Create(variant).Error
+}
+
+// GetByID retrieves a variant by ID
+func (r *ProductVariantRepository) GetByID(id uint) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	err := r.db.First(&variant, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("variant not found")
+		}
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// GetByIDWithLock retrieves a variant by ID with row lock (for transaction)
+func (r *ProductVariantRepository) GetByIDWithLock(tx *gorm.DB, id uint) (*models.ProductVariant, error) {
+	var variant models.ProductVariant
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&variant, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("variant not found")
+		}
+		return nil, err
+	}
+	return &variant, nil
+}
+
+// ListByProduct retrieves a product's variants ordered by sort order
+func (r *ProductVariantRepository) ListByProduct(productID uint) ([]models.ProductVariant, error) {
+	var variants []models.ProductVariant
+	err := r.db.Where("product_id = ?", productID).
+		Order("sort_order ASC, id ASC").
+		Find(&variants).Error
+	return variants, err
+}
+
+// Update saves a variant inside tx
+func (r *ProductVariantRepository) Update(tx *gorm.DB, variant *models.ProductVariant) error {
+	return tx.Save(variant).Error
+}
+
+// ExistsByLabel checks if another variant of the product already uses a label
+func (r *ProductVariantRepository) ExistsByLabel(productID uint, label string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ProductVariant{}).
+		Where("product_id = ? AND label = ? AND id <> ?", productID, label, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CountActiveByProduct counts a product's active variants inside tx
+func (r *ProductVariantRepository) CountActiveByProduct(tx *gorm.DB, productID uint) (int64, error) {
+	var count int64
+	err := tx.Model(&models.ProductVariant{}).
+		Where("product_id = ? AND status = ?", productID, "active").
+		Count(&count).Error
+	return count, err
+}
+
+// AdjustStock changes a variant's stock by delta inside tx
+// Returns error if stock would become negative. Callers record the change as an inventory movement.
+func (r *ProductVariantRepository) AdjustStock(tx *gorm.DB, variantID uint, delta int) error {
+	result := tx.Model(&models.ProductVariant{}).
+		Where("id = ? AND stock_quantity + ? >= 0", variantID, delta).
+		Update("stock_quantity", gorm.Expr("stock_quantity + ?", delta))
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.New("insufficient stock or variant not found")
+	}
+
+	return nil
+}
+
+// SyncProductStock sets a product's stock to the total of its active variants inside tx
+func (r *ProductVariantRepository) SyncProductStock(tx *gorm.DB, productID uint) error {
+	total := tx.Model(&models.ProductVariant{}).
+		Select("COALESCE(SUM(stock_quantity), 0)").
+		Where("product_id = ? AND status = ?", productID, "active")
+
+	return tx.Model(&models.Product{}).
+		Where("id = ?", productID).
+		Update("stock_quantity", total).Error
+}
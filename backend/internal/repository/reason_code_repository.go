@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ReasonCodeRepository handles reason code data access operations
+type ReasonCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewReasonCodeRepository creates a new ReasonCodeRepository instance
+func NewReasonCodeRepository(db *gorm.DB) *ReasonCodeRepository {
+	return &ReasonCodeRepository{db: db}
+}
+
+// Create creates a new reason code
+func (r *ReasonCodeRepository) Create(reasonCode *models.ReasonCode) error {
+	return r.db.Create(reasonCode).Error
+}
+
+// GetByID retrieves a reason code by ID
+func (r *ReasonCodeRepository) GetByID(id uint) (*models.ReasonCode, error) {
+	var reasonCode models.ReasonCode
+	err := r.db.First(&reasonCode, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reason code not found")
+		}
+		return nil, err
+	}
+	return &reasonCode, nil
+}
+
+// GetByCode retrieves a reason code by its code
+func (r *ReasonCodeRepository) GetByCode(code string) (*models.ReasonCode, error) {
+	var reasonCode models.ReasonCode
+	err := r.db.Where("code = ?", code).First(&reasonCode).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("reason code not found")
+		}
+		return nil, err
+	}
+	return &reasonCode, nil
+}
+
+// List retrieves reason codes with optional status filter, ordered by code
+func (r *ReasonCodeRepository) List(status *string) ([]models.ReasonCode, error) {
+	var reasonCodes []models.ReasonCode
+	query := r.db
+
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	err := query.Order("code ASC").Find(&reasonCodes).Error
+	return reasonCodes, err
+}
+
+// Update saves a reason code
+func (r *ReasonCodeRepository) Update(reasonCode *models.ReasonCode) error {
+	return r.db.Save(reasonCode).Error
+}
+
+// UpdateStatus updates a reason code's status
+func (r *ReasonCodeRepository) UpdateStatus(id uint, status string) error {
+	return r.db.Model(&models.ReasonCode{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
+
+// ExistsByCode checks if a reason code already exists
+func (r *ReasonCodeRepository) ExistsByCode(code string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.ReasonCode{}).
+		Where("code = ?", code).
+		Count(&count).Error
+	return count > 0, err
+}
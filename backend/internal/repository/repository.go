@@ -1,23 +1,67 @@
 package repository
 
 import (
+	"strings"
+
 	"gorm.io/gorm"
 )
 
 // Repositories holds all repository instances
 type Repositories struct {
-	User              *UserRepository
-	Product           *ProductRepository
-	RedemptionOrder   *RedemptionOrderRepository
-	PointsTransaction *PointsTransactionRepository
+	User               *UserRepository
+	Product            *ProductRepository
+	RedemptionOrder    *RedemptionOrderRepository
+	PointsTransaction  *PointsTransactionRepository
+	IdempotencyKey     *IdempotencyKeyRepository
+	WelcomeBonusPolicy *WelcomeBonusPolicyRepository
+	Campaign           *CampaignRepository
+	PointsStatement    *PointsStatementRepository
+	UserWallet         *UserWalletRepository
+	ManagerAllowance   *ManagerAllowanceRepository
+	GrantApproval      *PointsGrantApprovalRepository
+	UserSegment        *UserSegmentRepository
+	ReasonCode         *ReasonCodeRepository
+	Category           *CategoryRepository
+	Tag                *TagRepository
+	ProductVariant     *ProductVariantRepository
+	ProductImage       *ProductImageRepository
+	ProductSearch      ProductSearcher
+	PriceHistory       *ProductPriceHistoryRepository
+	PriceSchedule      *ScheduledPriceChangeRepository
+	InventoryMovement  *InventoryMovementRepository
+	Notification       *NotificationRepository
 }
 
 // NewRepositories creates and initializes all repositories
 func NewRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		User:              NewUserRepository(db),
-		Product:           NewProductRepository(db),
-		RedemptionOrder:   NewRedemptionOrderRepository(db),
-		PointsTransaction: NewPointsTransactionRepository(db),
+		User:               NewUserRepository(db),
+		Product:            NewProductRepository(db),
+		RedemptionOrder:    NewRedemptionOrderRepository(db),
+		PointsTransaction:  NewPointsTransactionRepository(db),
+		IdempotencyKey:     NewIdempotencyKeyRepository(db),
+		WelcomeBonusPolicy: NewWelcomeBonusPolicyRepository(db),
+		Campaign:           NewCampaignRepository(db),
+		PointsStatement:    NewPointsStatementRepository(db),
+		UserWallet:         NewUserWalletRepository(db),
+		ManagerAllowance:   NewManagerAllowanceRepository(db),
+		GrantApproval:      NewPointsGrantApprovalRepository(db),
+		UserSegment:        NewUserSegmentRepository(db),
+		ReasonCode:         NewReasonCodeRepository(db),
+		Category:           NewCategoryRepository(db),
+		Tag:                NewTagRepository(db),
+		ProductVariant:     NewProductVariantRepository(db),
+		ProductImage:       NewProductImageRepository(db),
+		ProductSearch:      NewMySQLProductSearcher(db),
+		PriceHistory:       NewProductPriceHistoryRepository(db),
+		PriceSchedule:      NewScheduledPriceChangeRepository(db),
+		InventoryMovement:  NewInventoryMovementRepository(db),
+		Notification:       NewNotificationRepository(db),
 	}
 }
+
+// escapeLike escapes LIKE wildcards so user input is matched literally
+func escapeLike(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(s)
+}
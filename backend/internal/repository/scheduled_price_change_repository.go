@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledPriceChangeRepository handles scheduled price change data access operations
+type ScheduledPriceChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewScheduledPriceChangeRepository creates a new ScheduledPriceChangeRepository instance
+func NewScheduledPriceChangeRepository(db *gorm.DB) *ScheduledPriceChangeRepository {
+	return &ScheduledPriceChangeRepository{db: db}
+}
+
+// Create creates a new scheduled price change
+func (r *ScheduledPriceChangeRepository) Create(change *models.ScheduledPriceChange) error {
+	return r.db.Create(change).Error
+}
+
+// GetByID retrieves a scheduled price change by ID
+func (r *ScheduledPriceChangeRepository) GetByID(id uint) (*models.ScheduledPriceChange, error) {
+	var change models.ScheduledPriceChange
+	err := r.db.Preload("Product").Preload("Operator").First(&change, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("scheduled price change not found")
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+// List retrieves scheduled price changes with optional status and product filters, soonest first
+func (r *ScheduledPriceChangeRepository) List(status *string, productID *uint) ([]models.ScheduledPriceChange, error) {
+	var changes []models.ScheduledPriceChange
+	query := r.db.Preload("Product").Preload("Operator")
+
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+	if productID != nil {
+		query = query.Where("product_id = ?", *productID)
+	}
+
+	err := query.Order("starts_at ASC").Order("id ASC").Find(&changes).Error
+	return changes, err
+}
+
+// ListOpenByProduct retrieves a product's pending and active changes
+func (r *ScheduledPriceChangeRepository) ListOpenByProduct(productID uint) ([]models.ScheduledPriceChange, error) {
+	var changes []models.ScheduledPriceChange
+	err := r.db.Where("product_id = ? AND status IN ?", productID,
+		[]string{models.PriceChangePending, models.PriceChangeActive}).
+		Order("starts_at ASC").
+		Find(&changes).Error
+	return changes, err
+}
+
+// ListDueToApply retrieves pending changes whose start time has passed, in start order
+func (r *ScheduledPriceChangeRepository) ListDueToApply(now time.Time) ([]models.ScheduledPriceChange, error) {
+	var changes []models.ScheduledPriceChange
+	err := r.db.Where("status = ? AND starts_at <= ?", models.PriceChangePending, now).
+		Order("starts_at ASC").
+		Order("id ASC").
+		Find(&changes).Error
+	return changes, err
+}
+
+// ListDueToRevert retrieves applied temporary changes whose end time has passed, in end order
+func (r *ScheduledPriceChangeRepository) ListDueToRevert(now time.Time) ([]models.ScheduledPriceChange, error) {
+	var changes []models.ScheduledPriceChange
+	err := r.db.Where("status = ? AND ends_at IS NOT NULL AND ends_at <= ?", models.PriceChangeActive, now).
+		Order("ends_at ASC").
+		Order("id ASC").
+		Find(&changes).Error
+	return changes, err
+}
+
+// Transition updates a change only if it still has status from
+// It reports whether the change was updated, so that concurrent schedulers and admins
+// cannot both act on the same change.
+func (r *ScheduledPriceChangeRepository) Transition(id uint, from string, updates map[string]interface{}) (bool, error) {
+	result := r.db.Model(&models.ScheduledPriceChange{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(updates)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
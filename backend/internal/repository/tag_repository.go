@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository handles product tag data access operations
+type TagRepository struct {
+	db *gorm.DB
+}
+
+// NewTagRepository creates a new TagRepository instance
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{db: db}
+}
+
+// Create creates a new tag
+func (r *TagRepository) Create(tag *models.Tag) error {
+	return r.db.Create(tag).Error
+}
+
+// GetByID retrieves a tag by ID
+func (r *TagRepository) GetByID(id uint) (*models.Tag, error) {
+	var tag models.Tag
+	err := r.db.First(&tag, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("tag not found")
+		}
+		return nil, err
+	}
+	return &tag, nil
+}
+
+// TagWithCount is a tag together with the number of products carrying it
+type TagWithCount struct {
+	models.Tag
+	ProductCount int `json:"product_count"`
+}
+
+// ListWithCounts retrieves all tags ordered by name with their product counts
+func (r *TagRepository) ListWithCounts() ([]TagWithCount, error) {
+	var tags []TagWithCount
+	err := r.db.Table("tags").
+		Select("tags.*, COUNT(product_tags.product_id) as product_count").
+		Joins("LEFT JOIN product_tags ON product_tags.tag_id = tags.id").
+		Group("tags.id").
+		Order("tags.name ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+// ExistsByName checks if another tag already uses a name
+func (r *TagRepository) ExistsByName(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Tag{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// UpdateName renames a tag
+func (r *TagRepository) UpdateName(id uint, name string) error {
+	return r.db.Model(&models.Tag{}).
+		Where("id = ?", id).
+		Update("name", name).Error
+}
+
+// Delete deletes a tag and removes it from every product
+func (r *TagRepository) Delete(id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM product_tags WHERE tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Tag{}, id).Error
+	})
+}
+
+// FindOrCreateByNames returns the tags with the given names inside tx, creating any that do not exist
+func (r *TagRepository) FindOrCreateByNames(tx *gorm.DB, names []string) ([]models.Tag, error) {
+	if len(names) == 0 {
+		return []models.Tag{}, nil
+	}
+
+	newTags := make([]models.Tag, 0, len(names))
+	for _, name := range names {
+		newTags = append(newTags, models.Tag{Name: name})
+	}
+
+	err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&newTags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []models.Tag
+	err = tx.Where("name IN ?", names).Order("name ASC").Find(&tags).Error
+	return tags, err
+}
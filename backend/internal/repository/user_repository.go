@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository handles user data access operations
@@ -59,12 +60,25 @@ func (r *UserRepository) Delete(id uint) error {
 }
 
 // UpdatePointsBalance updates a user's points balance
+// It does not write a points transaction, so the ledger will no longer match the balance;
+// use ReconciliationService to detect and correct the difference
 func (r *UserRepository) UpdatePointsBalance(userID uint, newBalance int) error {
 	return r.db.Model(&models.User{}).
 		Where("id = ?", userID).
 		Update("points_balance", newBalance).Error
 }
 
+// GetByIDsWithLock retrieves users by IDs with row locks (for transaction)
+// Rows are locked in ascending ID order to avoid deadlocks between concurrent batches
+func (r *UserRepository) GetByIDsWithLock(tx *gorm.DB, ids []uint) ([]models.User, error) {
+	var users []models.User
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Find(&users).Error
+	return users, err
+}
+
 // UpdateStatus updates a user's active status (for handling employee departure)
 func (r *UserRepository) UpdateStatus(userID uint, isActive bool) error {
 	return r.db.Model(&models.User{}).
@@ -79,6 +93,22 @@ func (r *UserRepository) UpdatePhone(userID uint, phone string) error {
 		Update("phone", phone).Error
 }
 
+// UpdateManager sets or clears a user's manager
+func (r *UserRepository) UpdateManager(userID uint, managerID *uint) error {
+	return r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("manager_id", managerID).Error
+}
+
+// GetByManagerID retrieves a manager's direct reports
+func (r *UserRepository) GetByManagerID(managerID uint) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("manager_id = ?", managerID).
+		Order("full_name ASC").
+		Find(&users).Error
+	return users, err
+}
+
 // UpdateFirstLoginFlag updates the is_first_login flag
 func (r *UserRepository) UpdateFirstLoginFlag(userID uint, isFirstLogin bool) error {
 	return r.db.Model(&models.User{}).
@@ -106,9 +136,58 @@ func (r *UserRepository) GetActiveUsers() ([]models.User, error) {
 	return users, err
 }
 
+// GetActiveAdmins retrieves all active admin users
+func (r *UserRepository) GetActiveAdmins() ([]models.User, error) {
+	var users []models.User
+	err := r.db.Where("is_active = ? AND role = ?", true, "admin").Find(&users).Error
+	return users, err
+}
+
 // ExistsByEmail checks if a user with the given email exists
 func (r *UserRepository) ExistsByEmail(email string) (bool, error) {
 	var count int64
 	err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
+
+// ListBySegment retrieves users matching a segment's filters in ID order
+// Only users with an ID greater than afterID are returned; a limit of 0 returns all of them.
+func (r *UserRepository) ListBySegment(segment *models.UserSegment, afterID uint, limit int) ([]models.User, error) {
+	var users []models.User
+	query := r.db.Where("id > ?", afterID)
+
+	switch segment.UserStatus {
+	case "active":
+		query = query.Where("is_active = ?", true)
+	case "inactive":
+		query = query.Where("is_active = ?", false)
+	}
+
+	if segment.Department != "" {
+		query = query.Where("department = ?", segment.Department)
+	}
+
+	if segment.Location != "" {
+		query = query.Where("location = ?", segment.Location)
+	}
+
+	if segment.Role != "" {
+		query = query.Where("role = ?", segment.Role)
+	}
+
+	if segment.JoinedFrom != nil {
+		query = query.Where("join_date >= ?", segment.JoinedFrom.Format("2006-01-02"))
+	}
+
+	if segment.JoinedTo != nil {
+		query = query.Where("join_date <= ?", segment.JoinedTo.Format("2006-01-02"))
+	}
+
+	query = query.Order("id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	err := query.Find(&users).Error
+	return users, err
+}
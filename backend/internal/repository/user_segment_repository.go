@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserSegmentRepository handles user segment and segment grant job data access operations
+type UserSegmentRepository struct {
+	db *gorm.DB
+}
+
+// NewUserSegmentRepository creates a new UserSegmentRepository instance
+func NewUserSegmentRepository(db *gorm.DB) *UserSegmentRepository {
+	return &UserSegmentRepository{db: db}
+}
+
+// Create creates a new segment
+func (r *UserSegmentRepository) Create(segment *models.UserSegment) error {
+	return r.db.Create(segment).Error
+}
+
+// GetByID retrieves a segment by ID
+func (r *UserSegmentRepository) GetByID(id uint) (*models.UserSegment, error) {
+	var segment models.UserSegment
+	err := r.db.First(&segment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("segment not found")
+		}
+		return nil, err
+	}
+	return &segment, nil
+}
+
+// List retrieves all segments ordered by name
+func (r *UserSegmentRepository) List() ([]models.UserSegment, error) {
+	var segments []models.UserSegment
+	err := r.db.Order("name ASC").Find(&segments).Error
+	return segments, err
+}
+
+// Update saves a segment's filters
+func (r *UserSegmentRepository) Update(segment *models.UserSegment) error {
+	return r.db.Save(segment).Error
+}
+
+// Delete deletes a segment
+func (r *UserSegmentRepository) Delete(id uint) error {
+	return r.db.Delete(&models.UserSegment{}, id).Error
+}
+
+// CreateGrantJob creates a new segment grant job
+func (r *UserSegmentRepository) CreateGrantJob(job *models.SegmentGrantJob) error {
+	return r.db.Create(job).Error
+}
+
+// GetGrantJobByID retrieves a segment grant job by ID
+func (r *UserSegmentRepository) GetGrantJobByID(id uint) (*models.SegmentGrantJob, error) {
+	var job models.SegmentGrantJob
+	err := r.db.Preload("Segment").Preload("Operator").Preload("ReasonCode").First(&job, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("grant job not found")
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListGrantJobs retrieves grant jobs, optionally for one segment (newest first)
+func (r *UserSegmentRepository) ListGrantJobs(segmentID *uint) ([]models.SegmentGrantJob, error) {
+	var jobs []models.SegmentGrantJob
+	query := r.db.Preload("Segment").Preload("Operator").Preload("ReasonCode")
+
+	if segmentID != nil {
+		query = query.Where("segment_id = ?", *segmentID)
+	}
+
+	err := query.Order("id DESC").Find(&jobs).Error
+	return jobs, err
+}
+
+// ExistsGrantJobForSegment checks if any grant job was created for a segment
+func (r *UserSegmentRepository) ExistsGrantJobForSegment(segmentID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.SegmentGrantJob{}).
+		Where("segment_id = ?", segmentID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ClaimGrantJob marks a job as running if it is pending, failed, or running without progress since staleBefore
+// It reports false when another runner holds the job or it has already completed.
+func (r *UserSegmentRepository) ClaimGrantJob(id uint, staleBefore time.Time) (bool, error) {
+	now := time.Now()
+	result := r.db.Model(&models.SegmentGrantJob{}).
+		Where("id = ?", id).
+		Where("status IN ? OR (status = ? AND updated_at < ?)", []string{"pending", "failed"}, "running", staleBefore).
+		Updates(map[string]interface{}{
+			"status":      "running",
+			"error":       "",
+			"started_at":  gorm.Expr("COALESCE(started_at, ?)", now),
+			"finished_at": nil,
+			"updated_at":  now,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// UpdateGrantJobProgress records a chunk's progress inside tx
+// The update only applies while the job's last_user_id is still previousLastUserID. It reports
+// false when another runner has already recorded this chunk, so the caller must roll back.
+func (r *UserSegmentRepository) UpdateGrantJobProgress(tx *gorm.DB, job *models.SegmentGrantJob, previousLastUserID uint) (bool, error) {
+	result := tx.Model(&models.SegmentGrantJob{}).
+		Where("id = ? AND last_user_id = ?", job.ID, previousLastUserID).
+		Updates(map[string]interface{}{
+			"granted_users": job.GrantedUsers,
+			"skipped_users": job.SkippedUsers,
+			"total_granted": job.TotalGranted,
+			"last_user_id":  job.LastUserID,
+			"updated_at":    time.Now(),
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+// FinishGrantJob sets a job's final status and error message
+func (r *UserSegmentRepository) FinishGrantJob(id uint, status string, errMsg string) error {
+	return r.db.Model(&models.SegmentGrantJob{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"error":       errMsg,
+			"finished_at": time.Now(),
+		}).Error
+}
+
+// ExistsByName checks if another segment already uses a name
+func (r *UserSegmentRepository) ExistsByName(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.UserSegment{}).
+		Where("name = ? AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserWalletRepository handles user wallet data access operations
+type UserWalletRepository struct {
+	db *gorm.DB
+}
+
+// NewUserWalletRepository creates a new UserWalletRepository instance
+func NewUserWalletRepository(db *gorm.DB) *UserWalletRepository {
+	return &UserWalletRepository{db: db}
+}
+
+// GetByUserID retrieves all wallets for a user
+func (r *UserWalletRepository) GetByUserID(userID uint) ([]models.UserWallet, error) {
+	var wallets []models.UserWallet
+	err := r.db.Where("user_id = ?", userID).
+		Order("wallet ASC").
+		Find(&wallets).Error
+	return wallets, err
+}
+
+// GetBalances retrieves wallet balances for several users inside tx, keyed by user ID and wallet
+// Callers lock the user rows first; every balance change holds that lock.
+func (r *UserWalletRepository) GetBalances(tx *gorm.DB, userIDs []uint) (map[uint]map[string]int, error) {
+	var wallets []models.UserWallet
+	err := tx.Where("user_id IN ?", userIDs).Find(&wallets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[uint]map[string]int, len(userIDs))
+	for _, userID := range userIDs {
+		balances[userID] = make(map[string]int)
+	}
+	for _, wallet := range wallets {
+		balances[wallet.UserID][wallet.Wallet] = wallet.Balance
+	}
+	return balances, nil
+}
+
+// AdjustBalance adds delta to a user's wallet balance inside tx, creating the wallet if needed
+func (r *UserWalletRepository) AdjustBalance(tx *gorm.DB, userID uint, wallet string, delta int) error {
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "wallet"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"balance":    gorm.Expr("balance + ?", delta),
+			"updated_at": time.Now(),
+		}),
+	}).Create(&models.UserWallet{
+		UserID:  userID,
+		Wallet:  wallet,
+		Balance: delta,
+	}).Error
+}
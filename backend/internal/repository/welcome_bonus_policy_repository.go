@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrWelcomeBonusPolicyNotFound is returned by Get before any policy has been saved
+var ErrWelcomeBonusPolicyNotFound = errors.New("welcome bonus policy not found")
+
+// WelcomeBonusPolicyRepository handles welcome bonus policy data access operations
+type WelcomeBonusPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewWelcomeBonusPolicyRepository creates a new WelcomeBonusPolicyRepository instance
+func NewWelcomeBonusPolicyRepository(db *gorm.DB) *WelcomeBonusPolicyRepository {
+	return &WelcomeBonusPolicyRepository{db: db}
+}
+
+// Get retrieves the welcome bonus policy with its rules
+func (r *WelcomeBonusPolicyRepository) Get() (*models.WelcomeBonusPolicy, error) {
+	var policy models.WelcomeBonusPolicy
+	err := r.db.Preload("Rules").Order("id ASC").First(&policy).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWelcomeBonusPolicyNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// Save creates or updates the policy and replaces its rules in a single transaction
+func (r *WelcomeBonusPolicyRepository) Save(policy *models.WelcomeBonusPolicy) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		rules := policy.Rules
+		policy.Rules = nil
+
+		if err := tx.Save(policy).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("policy_id = ?", policy.ID).Delete(&models.WelcomeBonusRule{}).Error; err != nil {
+			return err
+		}
+
+		for i := range rules {
+			rules[i].ID = 0
+			rules[i].PolicyID = policy.ID
+		}
+		if len(rules) > 0 {
+			if err := tx.Create(&rules).Error; err != nil {
+				return err
+			}
+		}
+
+		policy.Rules = rules
+		return nil
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"awsome-shop/internal/middleware"
 	"awsome-shop/internal/repository"
 	"awsome-shop/internal/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -28,7 +29,13 @@ func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	repos := repository.NewRepositories(db)
 
 	// Initialize services
-	services := service.NewServices(repos, db, cfg.JWT.Secret, cfg.JWT.ExpirationHours)
+	services := service.NewServices(repos, db, cfg)
+
+	// Start background jobs
+	go services.Idempotency.RunCleanup(time.Hour)
+	go services.Statement.RunMonthlyGeneration(time.Hour)
+	go services.PriceSchedule.RunScheduler(time.Minute)
+	go services.Product.RunAvailabilityScheduler(time.Minute)
 
 	// Initialize handlers
 	handlers := handler.NewHandlers(services)
@@ -46,6 +53,7 @@ func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
 
 	// API v1 routes
 	v1 := r.Group("/api/v1")
+	v1.Use(middleware.IdempotencyMiddleware(services.Auth, services.Idempotency))
 	{
 		// Register all routes
 		handlers.Auth.RegisterRoutes(v1, authMiddleware)
@@ -53,6 +61,8 @@ func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
 		handlers.Product.RegisterRoutes(v1, authMiddleware)
 		handlers.Redemption.RegisterRoutes(v1, authMiddleware)
 		handlers.Points.RegisterRoutes(v1, authMiddleware)
+		handlers.Manager.RegisterRoutes(v1, authMiddleware)
+		handlers.Notification.RegisterRoutes(v1, authMiddleware)
 		
 		// Admin routes
 		handlers.AdminUser.RegisterRoutes(v1, authMiddleware, adminMiddleware)
@@ -60,6 +70,15 @@ func Setup(db *gorm.DB, cfg *config.Config) *gin.Engine {
 		handlers.AdminPoints.RegisterRoutes(v1, authMiddleware, adminMiddleware)
 		handlers.AdminOrder.RegisterRoutes(v1, authMiddleware, adminMiddleware)
 		handlers.AdminReport.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.AdminSettings.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.AdminCampaign.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.AdminAllowance.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.AdminSegment.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.ReasonCode.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.Category.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.ProductImage.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.PriceSchedule.RegisterRoutes(v1, authMiddleware, adminMiddleware)
+		handlers.AdminInventory.RegisterRoutes(v1, authMiddleware, adminMiddleware)
 	}
 
 	return r
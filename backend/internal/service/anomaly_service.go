@@ -0,0 +1,174 @@
+package service
+
+import (
+	"awsome-shop/internal/config"
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Anomaly types reported by AnomalyService
+const (
+	AnomalyLargeGrantBeforeRedemption = "large_grant_before_redemption"
+	AnomalyOutsideWorkingHours        = "outside_working_hours"
+	AnomalyRepeatedRoundGrants        = "repeated_round_grants"
+)
+
+// AnomalyService flags unusual patterns in operator grants for review
+type AnomalyService struct {
+	pointsTransactionRepo *repository.PointsTransactionRepository
+	cfg                   config.AnomalyConfig
+}
+
+// NewAnomalyService creates a new AnomalyService instance
+func NewAnomalyService(
+	pointsTransactionRepo *repository.PointsTransactionRepository,
+	cfg config.AnomalyConfig,
+) *AnomalyService {
+	return &AnomalyService{
+		pointsTransactionRepo: pointsTransactionRepo,
+		cfg:                   cfg,
+	}
+}
+
+// PointsAnomaly is one flagged pattern and the grant transactions involved
+type PointsAnomaly struct {
+	Type           string    `json:"type"`
+	UserID         uint      `json:"user_id"`
+	UserEmail      string    `json:"user_email"`
+	OperatorID     uint      `json:"operator_id"`
+	OperatorEmail  string    `json:"operator_email"`
+	TransactionIDs []uint    `json:"transaction_ids"`
+	Amount         int       `json:"amount"` // Total granted across the transactions
+	CreatedAt      time.Time `json:"created_at"`
+	Detail         string    `json:"detail"`
+}
+
+// AnomalyReport lists the anomalies found in grants made in [From, To)
+type AnomalyReport struct {
+	From        time.Time       `json:"from"`
+	To          time.Time       `json:"to"`
+	Anomalies   []PointsAnomaly `json:"anomalies"`
+	GeneratedAt time.Time       `json:"generated_at"`
+}
+
+// newGrantAnomaly builds an anomaly for a single grant transaction
+func newGrantAnomaly(anomalyType string, grant *models.PointsTransaction, detail string) PointsAnomaly {
+	anomaly := PointsAnomaly{
+		Type:           anomalyType,
+		UserID:         grant.UserID,
+		UserEmail:      grant.User.Email,
+		TransactionIDs: []uint{grant.ID},
+		Amount:         grant.Amount,
+		CreatedAt:      grant.CreatedAt,
+		Detail:         detail,
+	}
+	if grant.OperatorID != nil {
+		anomaly.OperatorID = *grant.OperatorID
+	}
+	if grant.Operator != nil {
+		anomaly.OperatorEmail = grant.Operator.Email
+	}
+	return anomaly
+}
+
+// isOutsideWorkingHours reports whether t (local time) falls on a weekend or outside the workday
+func (s *AnomalyService) isOutsideWorkingHours(t time.Time) bool {
+	t = t.Local()
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return true
+	}
+	return t.Hour() < s.cfg.WorkdayStartHour || t.Hour() >= s.cfg.WorkdayEndHour
+}
+
+// isRoundNumber reports whether an amount is a multiple of the configured unit
+func (s *AnomalyService) isRoundNumber(amount int) bool {
+	return s.cfg.RoundNumberUnit > 0 && amount%s.cfg.RoundNumberUnit == 0
+}
+
+// GetAnomalyReport checks operator grants made in [from, to) for suspicious patterns
+// Nil bounds default to the configured lookback period ending now. Flagged grants are
+// candidates for review, not proof of misuse.
+func (s *AnomalyService) GetAnomalyReport(from, to *time.Time) (*AnomalyReport, error) {
+	now := time.Now()
+	report := &AnomalyReport{
+		From:        now.AddDate(0, 0, -s.cfg.LookbackDays),
+		To:          now,
+		Anomalies:   []PointsAnomaly{},
+		GeneratedAt: now,
+	}
+	if from != nil {
+		report.From = *from
+	}
+	if to != nil {
+		report.To = *to
+	}
+
+	if !report.From.Before(report.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	grants, err := s.pointsTransactionRepo.GetOperatorGrantsInRange(report.From, report.To)
+	if err != nil {
+		return nil, err
+	}
+
+	window := time.Duration(s.cfg.RedemptionWindowHours) * time.Hour
+
+	type operatorUser struct {
+		operatorID uint
+		userID     uint
+	}
+	var pairOrder []operatorUser
+	roundGrants := make(map[operatorUser][]*models.PointsTransaction)
+
+	for i := range grants {
+		grant := &grants[i]
+
+		if grant.Amount >= s.cfg.LargeGrantAmount {
+			redemption, err := s.pointsTransactionRepo.GetFirstRedemptionInRange(grant.UserID, grant.CreatedAt, grant.CreatedAt.Add(window))
+			if err != nil {
+				return nil, err
+			}
+			if redemption != nil {
+				detail := fmt.Sprintf("redeemed %d points %s after the grant", -redemption.Amount, redemption.CreatedAt.Sub(grant.CreatedAt).Round(time.Minute))
+				report.Anomalies = append(report.Anomalies, newGrantAnomaly(AnomalyLargeGrantBeforeRedemption, grant, detail))
+			}
+		}
+
+		if s.isOutsideWorkingHours(grant.CreatedAt) {
+			detail := fmt.Sprintf("granted at %s", grant.CreatedAt.Local().Format("Mon 15:04"))
+			report.Anomalies = append(report.Anomalies, newGrantAnomaly(AnomalyOutsideWorkingHours, grant, detail))
+		}
+
+		if s.isRoundNumber(grant.Amount) {
+			key := operatorUser{operatorID: *grant.OperatorID, userID: grant.UserID}
+			if _, ok := roundGrants[key]; !ok {
+				pairOrder = append(pairOrder, key)
+			}
+			roundGrants[key] = append(roundGrants[key], grant)
+		}
+	}
+
+	for _, key := range pairOrder {
+		pairGrants := roundGrants[key]
+		if len(pairGrants) < s.cfg.RoundNumberRepeatCount {
+			continue
+		}
+
+		last := pairGrants[len(pairGrants)-1]
+		anomaly := newGrantAnomaly(AnomalyRepeatedRoundGrants, last, "")
+		anomaly.TransactionIDs = nil
+		anomaly.Amount = 0
+		for _, grant := range pairGrants {
+			anomaly.TransactionIDs = append(anomaly.TransactionIDs, grant.ID)
+			anomaly.Amount += grant.Amount
+		}
+		anomaly.Detail = fmt.Sprintf("%d grants in multiples of %d from the same operator", len(pairGrants), s.cfg.RoundNumberUnit)
+		report.Anomalies = append(report.Anomalies, anomaly)
+	}
+
+	return report, nil
+}
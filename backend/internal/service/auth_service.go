@@ -15,6 +15,7 @@ import (
 type AuthService struct {
 	userRepo            *repository.UserRepository
 	pointsTransactionRepo *repository.PointsTransactionRepository
+	welcomeBonusService *WelcomeBonusService
 	db                  *gorm.DB
 	jwtSecret           string
 	jwtExpirationHours  int
@@ -24,6 +25,7 @@ type AuthService struct {
 func NewAuthService(
 	userRepo *repository.UserRepository,
 	pointsTransactionRepo *repository.PointsTransactionRepository,
+	welcomeBonusService *WelcomeBonusService,
 	db *gorm.DB,
 	jwtSecret string,
 	jwtExpirationHours int,
@@ -31,6 +33,7 @@ func NewAuthService(
 	return &AuthService{
 		userRepo:            userRepo,
 		pointsTransactionRepo: pointsTransactionRepo,
+		welcomeBonusService: welcomeBonusService,
 		db:                  db,
 		jwtSecret:           jwtSecret,
 		jwtExpirationHours:  jwtExpirationHours,
@@ -119,7 +122,7 @@ type LoginResponse struct {
 }
 
 // Login authenticates a user and returns a JWT token
-// Handles first-time login by granting 1000 initial points
+// Handles first-time login by granting the welcome bonus when the policy grants it at first login
 func (s *AuthService) Login(email, password string) (*LoginResponse, error) {
 	// Get user by email
 	user, err := s.userRepo.GetByEmail(email)
@@ -161,7 +164,7 @@ func (s *AuthService) Login(email, password string) (*LoginResponse, error) {
 	}, nil
 }
 
-// handleFirstLogin handles first-time login by granting initial points
+// handleFirstLogin handles first-time login by granting the welcome bonus if due
 func (s *AuthService) handleFirstLogin(user *models.User) error {
 	// Start transaction
 	tx := s.db.Begin()
@@ -175,34 +178,29 @@ func (s *AuthService) handleFirstLogin(user *models.User) error {
 		}
 	}()
 
-	// Grant 1000 initial points
-	initialPoints := 1000
-	newBalance := user.PointsBalance + initialPoints
-
-	// Update user points balance
-	err := tx.Model(&models.User{}).
-		Where("id = ?", user.ID).
-		Updates(map[string]interface{}{
-			"points_balance":  newBalance,
-			"is_first_login": false,
-		}).Error
+	// Lock the user so a concurrent first login cannot grant the bonus twice
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, []uint{user.ID})
 	if err != nil {
 		tx.Rollback()
 		return err
 	}
+	if len(lockedUsers) == 0 {
+		tx.Rollback()
+		return errors.New("user not found")
+	}
+	lockedUser := lockedUsers[0]
 
-	// Create points transaction record
-	transaction := &models.PointsTransaction{
-		UserID:          user.ID,
-		TransactionType: "grant",
-		Amount:          initialPoints,
-		BalanceAfter:    newBalance,
-		Reason:          "首次登录奖励 / First login bonus",
-		OperatorID:      nil, // System operation
-		RelatedOrderID:  nil,
+	// Grant the welcome bonus if the policy grants it at first login
+	_, err = s.welcomeBonusService.GrantIfDue(tx, &lockedUser, WelcomeBonusAtFirstLogin)
+	if err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	err = tx.Create(transaction).Error
+	// Clear first login flag
+	err = tx.Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Update("is_first_login", false).Error
 	if err != nil {
 		tx.Rollback()
 		return err
@@ -215,7 +213,8 @@ func (s *AuthService) handleFirstLogin(user *models.User) error {
 	}
 
 	// Update user object
-	user.PointsBalance = newBalance
+	user.PointsBalance = lockedUser.PointsBalance
+	user.WelcomeBonusGrantedAt = lockedUser.WelcomeBonusGrantedAt
 	user.IsFirstLogin = false
 
 	return nil
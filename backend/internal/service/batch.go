@@ -0,0 +1,27 @@
+package service
+
+import (
+	"fmt"
+)
+
+// BatchOptions controls how a batch operation treats invalid rows
+type BatchOptions struct {
+	DryRun  bool // Validate and preview only, nothing is written
+	Partial bool // Apply the valid rows and report the failed ones instead of rejecting the batch
+}
+
+// BatchRowError describes a validation failure for a single row of a batch table
+type BatchRowError struct {
+	Row     int    `json:"row"`
+	Email   string `json:"email,omitempty"`
+	Message string `json:"message"`
+}
+
+// BatchValidationError is returned when one or more rows of a batch table fail validation
+type BatchValidationError struct {
+	Rows []BatchRowError
+}
+
+func (e *BatchValidationError) Error() string {
+	return fmt.Sprintf("%d row(s) failed validation", len(e.Rows))
+}
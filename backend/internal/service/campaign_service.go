@@ -0,0 +1,274 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"time"
+)
+
+// CampaignService handles promotional campaign operations
+type CampaignService struct {
+	campaignRepo    *repository.CampaignRepository
+	productRepo     *repository.ProductRepository
+	categoryRepo    *repository.CategoryRepository
+	categoryService *CategoryService
+}
+
+// NewCampaignService creates a new CampaignService instance
+func NewCampaignService(
+	campaignRepo *repository.CampaignRepository,
+	productRepo *repository.ProductRepository,
+	categoryRepo *repository.CategoryRepository,
+	categoryService *CategoryService,
+) *CampaignService {
+	return &CampaignService{
+		campaignRepo:    campaignRepo,
+		productRepo:     productRepo,
+		categoryRepo:    categoryRepo,
+		categoryService: categoryService,
+	}
+}
+
+// CampaignRequest represents a request to create or update a campaign
+type CampaignRequest struct {
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	StartsAt        time.Time `json:"starts_at"`
+	EndsAt          time.Time `json:"ends_at"`
+	RuleType        string    `json:"rule_type"`
+	DiscountPercent int       `json:"discount_percent"`
+	BonusPoints     int       `json:"bonus_points"`
+	AppliesToAll    bool      `json:"applies_to_all"`
+	ProductIDs      []uint    `json:"product_ids"`
+	CategoryIDs     []uint    `json:"category_ids"` // Each also covers its subcategories
+}
+
+// campaignTargets are the products and categories a campaign applies to
+type campaignTargets struct {
+	Products   []models.Product
+	Categories []models.Category
+}
+
+// validate checks a campaign request and loads its target products and categories
+func (s *CampaignService) validate(req *CampaignRequest) (*campaignTargets, error) {
+	if req.Name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	if !req.StartsAt.Before(req.EndsAt) {
+		return nil, errors.New("starts_at must be before ends_at")
+	}
+
+	switch req.RuleType {
+	case "discount":
+		if req.DiscountPercent <= 0 || req.DiscountPercent >= 100 {
+			return nil, errors.New("discount percent must be between 1 and 99")
+		}
+		if req.BonusPoints != 0 {
+			return nil, errors.New("bonus points are not allowed on discount campaigns")
+		}
+	case "bonus_points":
+		if req.BonusPoints <= 0 {
+			return nil, errors.New("bonus points must be greater than 0")
+		}
+		if req.DiscountPercent != 0 {
+			return nil, errors.New("discount percent is not allowed on bonus points campaigns")
+		}
+	default:
+		return nil, errors.New("invalid rule type: must be 'discount' or 'bonus_points'")
+	}
+
+	targets := &campaignTargets{}
+
+	if req.AppliesToAll {
+		if len(req.ProductIDs) > 0 || len(req.CategoryIDs) > 0 {
+			return nil, errors.New("product and category IDs cannot be set when the campaign applies to all products")
+		}
+		return targets, nil
+	}
+
+	if len(req.ProductIDs) == 0 && len(req.CategoryIDs) == 0 {
+		return nil, errors.New("at least one target product or category is required unless the campaign applies to all products")
+	}
+
+	for _, productID := range req.ProductIDs {
+		product, err := s.productRepo.GetByID(productID)
+		if err != nil {
+			return nil, err
+		}
+		targets.Products = append(targets.Products, *product)
+	}
+
+	for _, categoryID := range req.CategoryIDs {
+		category, err := s.categoryRepo.GetByID(categoryID)
+		if err != nil {
+			return nil, err
+		}
+		targets.Categories = append(targets.Categories, *category)
+	}
+
+	return targets, nil
+}
+
+// CreateCampaign creates a new campaign
+func (s *CampaignService) CreateCampaign(req *CampaignRequest, operatorID uint) (*models.Campaign, error) {
+	targets, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign := &models.Campaign{
+		Name:            req.Name,
+		Description:     req.Description,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		RuleType:        req.RuleType,
+		DiscountPercent: req.DiscountPercent,
+		BonusPoints:     req.BonusPoints,
+		AppliesToAll:    req.AppliesToAll,
+		Products:        targets.Products,
+		Categories:      targets.Categories,
+		Status:          "active",
+		CreatedBy:       &operatorID,
+	}
+
+	err = s.campaignRepo.Create(campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// UpdateCampaign replaces a campaign's settings and target products and categories
+func (s *CampaignService) UpdateCampaign(campaignID uint, req *CampaignRequest) (*models.Campaign, error) {
+	campaign, err := s.campaignRepo.GetByID(campaignID)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.validate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	campaign.Name = req.Name
+	campaign.Description = req.Description
+	campaign.StartsAt = req.StartsAt
+	campaign.EndsAt = req.EndsAt
+	campaign.RuleType = req.RuleType
+	campaign.DiscountPercent = req.DiscountPercent
+	campaign.BonusPoints = req.BonusPoints
+	campaign.AppliesToAll = req.AppliesToAll
+	campaign.Products = targets.Products
+	campaign.Categories = targets.Categories
+
+	err = s.campaignRepo.Update(campaign)
+	if err != nil {
+		return nil, err
+	}
+
+	return campaign, nil
+}
+
+// SetCampaignStatus sets a campaign's status (active/inactive)
+func (s *CampaignService) SetCampaignStatus(campaignID uint, status string) error {
+	if status != "active" && status != "inactive" {
+		return errors.New("invalid status: must be 'active' or 'inactive'")
+	}
+
+	if _, err := s.campaignRepo.GetByID(campaignID); err != nil {
+		return err
+	}
+
+	return s.campaignRepo.UpdateStatus(campaignID, status)
+}
+
+// GetCampaignByID retrieves a campaign by ID
+func (s *CampaignService) GetCampaignByID(campaignID uint) (*models.Campaign, error) {
+	return s.campaignRepo.GetByID(campaignID)
+}
+
+// ListCampaigns lists campaigns with optional status filter
+func (s *CampaignService) ListCampaigns(status *string) ([]models.Campaign, error) {
+	return s.campaignRepo.List(status)
+}
+
+// CampaignPrice is the outcome of applying campaigns to a product at checkout
+type CampaignPrice struct {
+	Campaign      *models.Campaign
+	OriginalPrice int
+	Price         int
+	BonusPoints   int
+}
+
+// PriceForProduct applies the best active campaign for a product at the given time
+// When several campaigns apply, the one worth the most points to the employee wins
+// (discount plus bonus), with the oldest campaign breaking ties.
+func (s *CampaignService) PriceForProduct(product *models.Product, at time.Time) (*CampaignPrice, error) {
+	return s.priceAt(product, product.PointsRequired, at)
+}
+
+// PriceForVariant applies the best active campaign to one of a product's variants
+// Campaigns target whole products, so they cover every variant, starting from the variant's own price.
+func (s *CampaignService) PriceForVariant(product *models.Product, variant *models.ProductVariant, at time.Time) (*CampaignPrice, error) {
+	return s.priceAt(product, variant.PointsFor(product), at)
+}
+
+// priceAt picks the best active campaign for a product given its list price
+// Campaigns targeting the product's category or any category above it apply too.
+func (s *CampaignService) priceAt(product *models.Product, listPrice int, at time.Time) (*CampaignPrice, error) {
+	price := &CampaignPrice{
+		OriginalPrice: listPrice,
+		Price:         listPrice,
+	}
+
+	var categoryIDs []uint
+	if product.CategoryID != nil {
+		ids, err := s.categoryService.GetCategoryWithAncestors(*product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		categoryIDs = ids
+	}
+
+	campaigns, err := s.campaignRepo.GetActiveForProduct(product.ID, categoryIDs, at)
+	if err != nil {
+		return nil, err
+	}
+
+	bestBenefit := 0
+	for i := range campaigns {
+		campaign := &campaigns[i]
+
+		discounted := listPrice
+		if campaign.RuleType == "discount" {
+			discounted = applyDiscount(listPrice, campaign.DiscountPercent)
+		}
+
+		benefit := listPrice - discounted + campaign.BonusPoints
+		if benefit > bestBenefit {
+			bestBenefit = benefit
+			price.Campaign = campaign
+			price.Price = discounted
+			price.BonusPoints = campaign.BonusPoints
+		}
+	}
+
+	return price, nil
+}
+
+// applyDiscount takes percent off a points price, rounding to the nearest point with a minimum of 1
+func applyDiscount(points, percent int) int {
+	discounted := (points*(100-percent) + 50) / 100
+	if discounted < 1 {
+		discounted = 1
+	}
+	return discounted
+}
+
+// GetEffectivenessReport gets redemption statistics for every campaign
+func (s *CampaignService) GetEffectivenessReport() ([]repository.CampaignEffectivenessStats, error) {
+	return s.campaignRepo.GetEffectivenessStats()
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCategoryDepth limits how deeply categories may be nested
+const maxCategoryDepth = 5
+
+// maxTagLength is the longest tag name that fits the tags table
+const maxTagLength = 50
+
+// CategoryService manages product categories and tags
+type CategoryService struct {
+	categoryRepo *repository.CategoryRepository
+	tagRepo      *repository.TagRepository
+}
+
+// NewCategoryService creates a new CategoryService instance
+func NewCategoryService(
+	categoryRepo *repository.CategoryRepository,
+	tagRepo *repository.TagRepository,
+) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+		tagRepo:      tagRepo,
+	}
+}
+
+// CategoryRequest represents a request to create or update a category
+type CategoryRequest struct {
+	ParentID  *uint  `json:"parent_id"` // Nil places the category at the top level
+	Name      string `json:"name"`
+	SortOrder int    `json:"sort_order"`
+}
+
+// CreateCategory adds a category under an optional parent
+func (s *CategoryService) CreateCategory(req *CategoryRequest) (*models.Category, error) {
+	category := &models.Category{
+		ParentID:  req.ParentID,
+		Name:      strings.TrimSpace(req.Name),
+		SortOrder: req.SortOrder,
+	}
+
+	if err := s.validateCategory(category); err != nil {
+		return nil, err
+	}
+
+	err := s.categoryRepo.Create(category)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// UpdateCategory renames, reorders or moves a category
+func (s *CategoryService) UpdateCategory(id uint, req *CategoryRequest) (*models.Category, error) {
+	category, err := s.categoryRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	category.ParentID = req.ParentID
+	category.Name = strings.TrimSpace(req.Name)
+	category.SortOrder = req.SortOrder
+
+	if err := s.validateCategory(category); err != nil {
+		return nil, err
+	}
+
+	err = s.categoryRepo.Update(category)
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// validateCategory checks a category's name and placement in the tree
+// A category cannot be moved under itself or one of its descendants, and the tree may not
+// grow deeper than maxCategoryDepth.
+func (s *CategoryService) validateCategory(category *models.Category) error {
+	if category.Name == "" {
+		return errors.New("name is required")
+	}
+	if strings.Contains(category.Name, "/") {
+		return errors.New("name cannot contain '/'")
+	}
+
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		return err
+	}
+
+	byID := make(map[uint]*models.Category, len(categories))
+	for i := range categories {
+		byID[categories[i].ID] = &categories[i]
+	}
+
+	depth := 1
+	if category.ParentID != nil {
+		parent, ok := byID[*category.ParentID]
+		if !ok {
+			return errors.New("parent category not found")
+		}
+
+		for node := parent; node != nil; {
+			if node.ID == category.ID {
+				return errors.New("a category cannot be moved under itself or its subcategories")
+			}
+			depth++
+			if node.ParentID == nil {
+				break
+			}
+			node = byID[*node.ParentID]
+		}
+	}
+
+	if depth+subtreeHeight(categories, category.ID) > maxCategoryDepth {
+		return fmt.Errorf("categories cannot be nested more than %d levels deep", maxCategoryDepth)
+	}
+
+	exists, err := s.categoryRepo.ExistsByParentAndName(category.ParentID, category.Name, category.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("a category with this name already exists at this level")
+	}
+
+	return nil
+}
+
+// subtreeHeight returns how many levels lie below a category, or 0 for a new one
+func subtreeHeight(categories []models.Category, id uint) int {
+	if id == 0 {
+		return 0
+	}
+
+	height := 0
+	for _, c := range categories {
+		if c.ParentID != nil && *c.ParentID == id {
+			if h := 1 + subtreeHeight(categories, c.ID); h > height {
+				height = h
+			}
+		}
+	}
+	return height
+}
+
+// DeleteCategory deletes a category that has no subcategories and no products
+func (s *CategoryService) DeleteCategory(id uint) error {
+	if _, err := s.categoryRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	children, err := s.categoryRepo.CountChildren(id)
+	if err != nil {
+		return err
+	}
+	if children > 0 {
+		return errors.New("category has subcategories; move or delete them first")
+	}
+
+	products, err := s.categoryRepo.CountProducts(id)
+	if err != nil {
+		return err
+	}
+	if products > 0 {
+		return errors.New("category still has products; reassign them first")
+	}
+
+	campaigns, err := s.categoryRepo.CountCampaigns(id)
+	if err != nil {
+		return err
+	}
+	if campaigns > 0 {
+		return errors.New("category is targeted by campaigns; remove it from them first")
+	}
+
+	return s.categoryRepo.Delete(id)
+}
+
+// GetCategoryByID retrieves a category by ID
+func (s *CategoryService) GetCategoryByID(id uint) (*models.Category, error) {
+	return s.categoryRepo.GetByID(id)
+}
+
+// ListCategories lists all categories as a flat list
+func (s *CategoryService) ListCategories() ([]models.Category, error) {
+	return s.categoryRepo.List()
+}
+
+// GetCategoryTree returns all categories nested under their parents
+func (s *CategoryService) GetCategoryTree() ([]models.Category, error) {
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildCategoryTree(categories, nil), nil
+}
+
+// buildCategoryTree nests the categories below parentID, keeping the repository's ordering
+func buildCategoryTree(categories []models.Category, parentID *uint) []models.Category {
+	var nodes []models.Category
+	for _, c := range categories {
+		if (parentID == nil && c.ParentID == nil) ||
+			(parentID != nil && c.ParentID != nil && *c.ParentID == *parentID) {
+			id := c.ID
+			c.Children = buildCategoryTree(categories, &id)
+			nodes = append(nodes, c)
+		}
+	}
+	return nodes
+}
+
+// GetCategoryWithDescendants returns the IDs of a category and every category below it
+// Filtering the catalog by a category includes products filed under its subcategories.
+func (s *CategoryService) GetCategoryWithDescendants(id uint) ([]uint, error) {
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, c := range categories {
+		if c.ID == id {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, errors.New("category not found")
+	}
+
+	ids := []uint{id}
+	for i := 0; i < len(ids); i++ {
+		for _, c := range categories {
+			if c.ParentID != nil && *c.ParentID == ids[i] {
+				ids = append(ids, c.ID)
+			}
+		}
+	}
+
+	return ids, nil
+}
+
+// GetCategoryWithAncestors returns the IDs of a category and every category above it
+// A campaign that targets a category covers products filed under its subcategories.
+func (s *CategoryService) GetCategoryWithAncestors(id uint) ([]uint, error) {
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	parents := make(map[uint]*uint, len(categories))
+	for _, c := range categories {
+		parents[c.ID] = c.ParentID
+	}
+
+	if _, ok := parents[id]; !ok {
+		return nil, errors.New("category not found")
+	}
+
+	ids := []uint{id}
+	for parentID := parents[id]; parentID != nil && len(ids) <= len(categories); parentID = parents[*parentID] {
+		ids = append(ids, *parentID)
+	}
+
+	return ids, nil
+}
+
+// FindCategoryByPath resolves a slash-separated path such as "数码/耳机" to a category
+func (s *CategoryService) FindCategoryByPath(path string) (*models.Category, error) {
+	categories, err := s.categoryRepo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return findCategoryByPath(categories, path)
+}
+
+// findCategoryByPath walks the category list one path segment at a time
+func findCategoryByPath(categories []models.Category, path string) (*models.Category, error) {
+	var current *models.Category
+	for _, segment := range strings.Split(path, "/") {
+		segment = strings.TrimSpace(segment)
+
+		var next *models.Category
+		for i := range categories {
+			c := &categories[i]
+			sameParent := (current == nil && c.ParentID == nil) ||
+				(current != nil && c.ParentID != nil && *c.ParentID == current.ID)
+			if sameParent && c.Name == segment {
+				next = c
+				break
+			}
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("category not found: %s", path)
+		}
+		current = next
+	}
+
+	return current, nil
+}
+
+// normalizeTagNames trims and de-duplicates tag names, dropping empty ones
+func normalizeTagNames(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	normalized := make([]string, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if utf8.RuneCountInString(name) > maxTagLength {
+			return nil, fmt.Errorf("tag is longer than %d characters: %s", maxTagLength, name)
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized, nil
+}
+
+// ListTags lists all tags with the number of products carrying each
+func (s *CategoryService) ListTags() ([]repository.TagWithCount, error) {
+	return s.tagRepo.ListWithCounts()
+}
+
+// CreateTag adds a tag
+func (s *CategoryService) CreateTag(name string) (*models.Tag, error) {
+	name, err := s.validateTagName(name, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := &models.Tag{Name: name}
+	err = s.tagRepo.Create(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
+
+// RenameTag renames a tag on every product that carries it
+func (s *CategoryService) RenameTag(id uint, name string) (*models.Tag, error) {
+	tag, err := s.tagRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err = s.validateTagName(name, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tagRepo.UpdateName(id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag.Name = name
+	return tag, nil
+}
+
+// DeleteTag deletes a tag and removes it from every product
+func (s *CategoryService) DeleteTag(id uint) error {
+	if _, err := s.tagRepo.GetByID(id); err != nil {
+		return err
+	}
+
+	return s.tagRepo.Delete(id)
+}
+
+// validateTagName normalizes a tag name and checks that no other tag uses it
+func (s *CategoryService) validateTagName(name string, excludeID uint) (string, error) {
+	names, err := normalizeTagNames([]string{name})
+	if err != nil {
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", errors.New("name is required")
+	}
+
+	exists, err := s.tagRepo.ExistsByName(names[0], excludeID)
+	if err != nil {
+		return "", err
+	}
+	if exists {
+		return "", errors.New("tag already exists")
+	}
+
+	return names[0], nil
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+)
+
+// ListGrantApprovals lists grant approvals with optional status filter
+func (s *PointsService) ListGrantApprovals(status *string) ([]models.PointsGrantApproval, error) {
+	return s.approvalRepo.List(status)
+}
+
+// ApproveGrant applies a pending grant to an admin on behalf of a second admin
+// The reviewer must be neither the admin who requested the grant nor its recipient. The
+// ledger entry keeps the requester as operator; the approval links to it.
+func (s *PointsService) ApproveGrant(approvalID uint, note string, reviewerID uint) (*models.PointsGrantApproval, error) {
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	approval, err := s.approvalRepo.GetByIDWithLock(tx, approvalID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if approval.Status != "pending" {
+		tx.Rollback()
+		return nil, errors.New("grant approval has already been reviewed")
+	}
+
+	if approval.RequestedBy == reviewerID {
+		tx.Rollback()
+		return nil, errors.New("a grant must be approved by a different admin than the one who requested it")
+	}
+
+	if approval.UserID == reviewerID {
+		tx.Rollback()
+		return nil, errors.New("cannot approve a grant to yourself")
+	}
+
+	user, err := s.userRepo.GetByID(approval.UserID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if !user.IsActive {
+		tx.Rollback()
+		return nil, errors.New("cannot grant points to inactive user")
+	}
+
+	transaction, err := s.grantPointsInTx(tx, approval.UserID, approval.Wallet, approval.Amount, approval.ReasonCodeID, approval.Reason, approval.RequestedBy, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	now := time.Now()
+	approval.Status = "approved"
+	approval.ReviewedBy = &reviewerID
+	approval.ReviewNote = note
+	approval.ReviewedAt = &now
+	approval.TransactionID = &transaction.ID
+
+	err = s.approvalRepo.UpdateReview(tx, approval)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.approvalRepo.GetByID(approval.ID)
+}
+
+// RejectGrant rejects a pending grant to an admin without writing anything to the ledger
+// The recipient cannot review their own grant; the requester may reject it to withdraw it.
+func (s *PointsService) RejectGrant(approvalID uint, note string, reviewerID uint) (*models.PointsGrantApproval, error) {
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	approval, err := s.approvalRepo.GetByIDWithLock(tx, approvalID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if approval.Status != "pending" {
+		tx.Rollback()
+		return nil, errors.New("grant approval has already been reviewed")
+	}
+
+	if approval.UserID == reviewerID {
+		tx.Rollback()
+		return nil, errors.New("cannot review a grant to yourself")
+	}
+
+	now := time.Now()
+	approval.Status = "rejected"
+	approval.ReviewedBy = &reviewerID
+	approval.ReviewNote = note
+	approval.ReviewedAt = &now
+
+	err = s.approvalRepo.UpdateReview(tx, approval)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return s.approvalRepo.GetByID(approval.ID)
+}
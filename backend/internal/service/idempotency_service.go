@@ -0,0 +1,143 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is replayed with a different request payload
+	ErrIdempotencyKeyReused = errors.New("idempotency key has already been used for a different request")
+
+	// ErrIdempotencyRequestInProgress is returned when the original request for a key has not finished yet
+	ErrIdempotencyRequestInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyService stores responses of mutating requests so retries can be replayed safely
+type IdempotencyService struct {
+	idempotencyKeyRepo *repository.IdempotencyKeyRepository
+	ttl                time.Duration
+	maxBodyBytes       int64
+}
+
+// NewIdempotencyService creates a new IdempotencyService instance
+func NewIdempotencyService(
+	idempotencyKeyRepo *repository.IdempotencyKeyRepository,
+	ttlHours int,
+	maxBodyMB int,
+) *IdempotencyService {
+	if ttlHours <= 0 {
+		ttlHours = 24 // Default retention window
+	}
+	if maxBodyMB <= 0 {
+		maxBodyMB = 8 // Large enough for an image upload and its multipart envelope
+	}
+
+	return &IdempotencyService{
+		idempotencyKeyRepo: idempotencyKeyRepo,
+		ttl:                time.Duration(ttlHours) * time.Hour,
+		maxBodyBytes:       int64(maxBodyMB) << 20,
+	}
+}
+
+// MaxBodyBytes is the largest request body buffered to fingerprint a request
+func (s *IdempotencyService) MaxBodyBytes() int64 {
+	return s.maxBodyBytes
+}
+
+// HashRequest computes the fingerprint used to detect a key reused with a different payload
+func HashRequest(method, path string, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(path))
+	hash.Write([]byte{0})
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Begin looks up an idempotency key for a user
+// If a completed response is stored for the same request it is returned with replay set to true.
+// Otherwise the key is reserved for this request and must be finished with Complete or Release.
+func (s *IdempotencyService) Begin(userID uint, key, method, path, requestHash string) (*models.IdempotencyKey, bool, error) {
+	existing, err := s.idempotencyKeyRepo.GetByUserAndKey(userID, key)
+	if err == nil {
+		if time.Now().After(existing.ExpiresAt) {
+			// Expired keys behave as if they were never used
+			if err := s.idempotencyKeyRepo.Delete(existing.ID); err != nil {
+				return nil, false, err
+			}
+		} else {
+			if existing.RequestHash != requestHash {
+				return nil, false, ErrIdempotencyKeyReused
+			}
+			if existing.ResponseStatus == 0 {
+				return nil, false, ErrIdempotencyRequestInProgress
+			}
+			return existing, true, nil
+		}
+	}
+
+	record := &models.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Method:      method,
+		Path:        path,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(s.ttl),
+	}
+
+	err = s.idempotencyKeyRepo.Create(record)
+	if err != nil {
+		// A concurrent request reserved the same key first
+		if _, lookupErr := s.idempotencyKeyRepo.GetByUserAndKey(userID, key); lookupErr == nil {
+			return nil, false, ErrIdempotencyRequestInProgress
+		}
+		return nil, false, err
+	}
+
+	return record, false, nil
+}
+
+// Complete stores the response for a reserved key
+// Server errors are not stored so the client can retry with the same key
+func (s *IdempotencyService) Complete(record *models.IdempotencyKey, status int, contentType, body string) error {
+	if status >= 500 {
+		return s.Release(record)
+	}
+
+	return s.idempotencyKeyRepo.SaveResponse(record.ID, status, contentType, body)
+}
+
+// Release drops a reserved key without storing a response
+func (s *IdempotencyService) Release(record *models.IdempotencyKey) error {
+	return s.idempotencyKeyRepo.Delete(record.ID)
+}
+
+// PurgeExpired deletes all expired idempotency keys
+func (s *IdempotencyService) PurgeExpired() (int64, error) {
+	return s.idempotencyKeyRepo.DeleteExpired(time.Now())
+}
+
+// RunCleanup purges expired idempotency keys on a fixed interval
+// It blocks, so it should be started in its own goroutine
+func (s *IdempotencyService) RunCleanup(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := s.PurgeExpired()
+		if err != nil {
+			log.Printf("Failed to purge expired idempotency keys: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Purged %d expired idempotency keys", deleted)
+		}
+	}
+}
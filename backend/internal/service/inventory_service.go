@@ -0,0 +1,216 @@
+package service
+
+import (
+	"awsome-shop/internal/events"
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// unitsPerRedemptionOrder is the stock a redemption order takes; each order redeems one unit
+const unitsPerRedemptionOrder = 1
+
+// InventoryService records stock movements entered by admins and serves stock history
+// Movements caused by redemptions and product edits are recorded by the services making them.
+type InventoryService struct {
+	productRepo  *repository.ProductRepository
+	variantRepo  *repository.ProductVariantRepository
+	orderRepo    *repository.RedemptionOrderRepository
+	movementRepo *repository.InventoryMovementRepository
+	events       *events.Bus
+	db           *gorm.DB
+}
+
+// NewInventoryService creates a new InventoryService instance
+func NewInventoryService(
+	productRepo *repository.ProductRepository,
+	variantRepo *repository.ProductVariantRepository,
+	orderRepo *repository.RedemptionOrderRepository,
+	movementRepo *repository.InventoryMovementRepository,
+	eventBus *events.Bus,
+	db *gorm.DB,
+) *InventoryService {
+	return &InventoryService{
+		productRepo:  productRepo,
+		variantRepo:  variantRepo,
+		orderRepo:    orderRepo,
+		movementRepo: movementRepo,
+		events:       eventBus,
+		db:           db,
+	}
+}
+
+// InventoryMovementRequest represents a stock movement entered by an admin
+// Quantity is a count of units: added for receive and return, removed for writeoff, and
+// signed for adjust.
+type InventoryMovementRequest struct {
+	VariantID      *uint  // Required for products with active variants
+	MovementType   string // receive, return, writeoff or adjust
+	Quantity       int
+	RelatedOrderID *uint // Required for returns
+	Note           string
+}
+
+// stockDelta validates a movement request and returns the signed change to stock
+func (req *InventoryMovementRequest) stockDelta() (int, error) {
+	switch req.MovementType {
+	case models.MovementReceive, models.MovementReturn:
+		if req.Quantity <= 0 {
+			return 0, errors.New("quantity must be greater than 0")
+		}
+		return req.Quantity, nil
+	case models.MovementWriteoff:
+		if req.Quantity <= 0 {
+			return 0, errors.New("quantity must be greater than 0")
+		}
+		return -req.Quantity, nil
+	case models.MovementAdjust:
+		if req.Quantity == 0 {
+			return 0, errors.New("quantity cannot be 0")
+		}
+		return req.Quantity, nil
+	default:
+		return 0, errors.New("invalid movement type: must be 'receive', 'return', 'writeoff' or 'adjust'")
+	}
+}
+
+// RecordMovement changes a product's (or variant's) stock and records why
+// Write-offs and adjustments need a note; returns must name the order the stock came back from
+// and cannot bring back more units than the order redeemed.
+func (s *InventoryService) RecordMovement(productID uint, req *InventoryMovementRequest, operatorID uint) (*models.InventoryMovement, error) {
+	delta, err := req.stockDelta()
+	if err != nil {
+		return nil, err
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > 500 {
+		return nil, errors.New("note cannot be longer than 500 characters")
+	}
+	if note == "" && (req.MovementType == models.MovementWriteoff || req.MovementType == models.MovementAdjust) {
+		return nil, errors.New("a note is required for write-offs and adjustments")
+	}
+
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.MovementType == models.MovementReturn {
+		if req.RelatedOrderID == nil {
+			return nil, errors.New("related_order_id is required for returns")
+		}
+		order, err := s.orderRepo.GetByID(*req.RelatedOrderID)
+		if err != nil {
+			return nil, err
+		}
+		if order.ProductID != productID || !sameVariant(order.VariantID, req.VariantID) {
+			return nil, errors.New("the related order is for a different product or variant")
+		}
+	}
+
+	if req.VariantID == nil && hasActiveVariants(product) {
+		return nil, errors.New("variant_id is required for this product")
+	}
+
+	movement := &models.InventoryMovement{
+		ProductID:      productID,
+		VariantID:      req.VariantID,
+		MovementType:   req.MovementType,
+		Quantity:       delta,
+		OperatorID:     &operatorID,
+		RelatedOrderID: req.RelatedOrderID,
+		Note:           note,
+	}
+
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the product first, in the same order as redemptions, to avoid deadlocks
+	locked, err := s.productRepo.GetByIDWithLock(tx, productID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Returns against the same order are serialized by the product lock above
+	if req.MovementType == models.MovementReturn {
+		returned, err := s.movementRepo.SumQuantityByOrder(tx, *req.RelatedOrderID, models.MovementReturn)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if returned+delta > unitsPerRedemptionOrder {
+			tx.Rollback()
+			return nil, fmt.Errorf("cannot return more than the %d unit(s) redeemed by the order; %d already returned", unitsPerRedemptionOrder, returned)
+		}
+	}
+
+	if req.VariantID != nil {
+		variant, err := s.variantRepo.GetByIDWithLock(tx, *req.VariantID)
+		if err != nil || variant.ProductID != productID {
+			tx.Rollback()
+			return nil, errors.New("variant not found")
+		}
+		movement.VariantLabel = variant.Label
+
+		err = s.variantRepo.AdjustStock(tx, variant.ID, delta)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		err = s.variantRepo.SyncProductStock(tx, productID)
+	} else {
+		err = s.productRepo.AdjustStock(tx, productID, delta)
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = s.movementRepo.Record(tx, movement)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	publishLowStock(s.events, locked, locked.StockQuantity, movement.ProductStockAfter)
+
+	return movement, nil
+}
+
+// sameVariant reports whether two optional variant IDs refer to the same variant
+func sameVariant(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// ListMovements retrieves a product's stock history with pagination, optionally for one variant
+func (s *InventoryService) ListMovements(productID uint, variantID *uint, page, pageSize int) ([]models.InventoryMovement, int64, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, 0, err
+	}
+
+	return s.movementRepo.ListByProduct(productID, variantID, page, pageSize)
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"awsome-shop/internal/repository"
+	"time"
+)
+
+// LedgerService verifies the tamper-evident hash chain of the points_transactions ledger
+type LedgerService struct {
+	userRepo              *repository.UserRepository
+	pointsTransactionRepo *repository.PointsTransactionRepository
+}
+
+// NewLedgerService creates a new LedgerService instance
+func NewLedgerService(
+	userRepo *repository.UserRepository,
+	pointsTransactionRepo *repository.PointsTransactionRepository,
+) *LedgerService {
+	return &LedgerService{
+		userRepo:              userRepo,
+		pointsTransactionRepo: pointsTransactionRepo,
+	}
+}
+
+// LedgerChainBreak describes a transaction that does not fit its user's hash chain
+// Problem is "content_modified" when the entry's stored hash does not match its content,
+// "unhashed" when the entry has no hash at all (the ledger is sealed once, so a blank hash
+// means it was cleared by hand), and "link_broken" when its prev_hash does not match the entry
+// before it (an entry was deleted, inserted or re-hashed).
+type LedgerChainBreak struct {
+	UserID        uint   `json:"user_id"`
+	UserEmail     string `json:"user_email"`
+	TransactionID uint   `json:"transaction_id"`
+	Problem       string `json:"problem"`
+	ExpectedHash  string `json:"expected_hash"`
+	RecordedHash  string `json:"recorded_hash"`
+}
+
+// LedgerVerificationReport is the result of walking every user's hash chain
+type LedgerVerificationReport struct {
+	CheckedUsers        int                `json:"checked_users"`
+	CheckedTransactions int                `json:"checked_transactions"`
+	Breaks              []LedgerChainBreak `json:"breaks"`
+	Intact              bool               `json:"intact"`
+	GeneratedAt         time.Time          `json:"generated_at"`
+}
+
+// VerifyChains recomputes every transaction hash and checks each link to the previous entry
+// Removing a user's most recent entry leaves a valid shorter chain; the reconciliation
+// report catches that case because the ledger no longer sums to the stored balance.
+func (s *LedgerService) VerifyChains() (*LedgerVerificationReport, error) {
+	users, err := s.userRepo.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LedgerVerificationReport{
+		Breaks:      []LedgerChainBreak{},
+		GeneratedAt: time.Now(),
+	}
+
+	for _, user := range users {
+		transactions, err := s.pointsTransactionRepo.GetByUserIDInLedgerOrder(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		report.CheckedUsers++
+		report.CheckedTransactions += len(transactions)
+
+		prevHash := ""
+		for i := range transactions {
+			transaction := &transactions[i]
+
+			if transaction.PrevHash != prevHash {
+				report.Breaks = append(report.Breaks, LedgerChainBreak{
+					UserID:        user.ID,
+					UserEmail:     user.Email,
+					TransactionID: transaction.ID,
+					Problem:       "link_broken",
+					ExpectedHash:  prevHash,
+					RecordedHash:  transaction.PrevHash,
+				})
+			}
+
+			if transaction.Hash == "" {
+				report.Breaks = append(report.Breaks, LedgerChainBreak{
+					UserID:        user.ID,
+					UserEmail:     user.Email,
+					TransactionID: transaction.ID,
+					Problem:       "unhashed",
+					ExpectedHash:  transaction.ComputeHash(),
+					RecordedHash:  "",
+				})
+			} else if expected := transaction.ComputeHash(); transaction.Hash != expected {
+				report.Breaks = append(report.Breaks, LedgerChainBreak{
+					UserID:        user.ID,
+					UserEmail:     user.Email,
+					TransactionID: transaction.ID,
+					Problem:       "content_modified",
+					ExpectedHash:  expected,
+					RecordedHash:  transaction.Hash,
+				})
+			}
+
+			// Continue from the recorded hash so one bad entry is reported once
+			prevHash = transaction.Hash
+		}
+	}
+
+	report.Intact = len(report.Breaks) == 0
+	return report, nil
+}
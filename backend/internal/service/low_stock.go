@@ -0,0 +1,23 @@
+package service
+
+import (
+	"awsome-shop/internal/events"
+	"awsome-shop/internal/models"
+)
+
+// publishLowStock raises a low-stock event when a stock change takes a product from above its
+// threshold down to it or below
+// Only the crossing is reported, so a product that stays low does not alert again on every
+// redemption. Call it after the change has been committed.
+func publishLowStock(eventBus *events.Bus, product *models.Product, before, after int) {
+	if before <= product.LowStockThreshold || after > product.LowStockThreshold {
+		return
+	}
+
+	eventBus.Publish(events.ProductLowStock, events.ProductLowStockPayload{
+		ProductID:     product.ID,
+		ProductName:   product.LocalizedName(models.LanguageZh),
+		StockQuantity: after,
+		Threshold:     product.LowStockThreshold,
+	})
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ManagerAllowanceService handles manager allowances and grants to direct reports
+type ManagerAllowanceService struct {
+	allowanceRepo *repository.ManagerAllowanceRepository
+	userRepo      *repository.UserRepository
+	pointsService *PointsService
+	db            *gorm.DB
+}
+
+// NewManagerAllowanceService creates a new ManagerAllowanceService instance
+func NewManagerAllowanceService(
+	allowanceRepo *repository.ManagerAllowanceRepository,
+	userRepo *repository.UserRepository,
+	pointsService *PointsService,
+	db *gorm.DB,
+) *ManagerAllowanceService {
+	return &ManagerAllowanceService{
+		allowanceRepo: allowanceRepo,
+		userRepo:      userRepo,
+		pointsService: pointsService,
+		db:            db,
+	}
+}
+
+// CreateAllowanceRequest represents a request to give a manager an allowance
+type CreateAllowanceRequest struct {
+	ManagerID uint      `json:"manager_id"`
+	Wallet    string    `json:"wallet"` // Defaults to recognition
+	Amount    int       `json:"amount"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Note      string    `json:"note"`
+}
+
+// CreateAllowance gives a manager a points budget for their direct reports
+func (s *ManagerAllowanceService) CreateAllowance(req *CreateAllowanceRequest, operatorID uint) (*models.ManagerAllowance, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	if !req.StartsAt.Before(req.EndsAt) {
+		return nil, errors.New("starts_at must be before ends_at")
+	}
+
+	wallet := req.Wallet
+	if wallet == "" {
+		wallet = models.WalletRecognition
+	}
+	wallet, err := normalizeWallet(wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	manager, err := s.userRepo.GetByID(req.ManagerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !manager.IsActive {
+		return nil, errors.New("manager is inactive")
+	}
+
+	allowance := &models.ManagerAllowance{
+		ManagerID: manager.ID,
+		Wallet:    wallet,
+		Amount:    req.Amount,
+		StartsAt:  req.StartsAt,
+		EndsAt:    req.EndsAt,
+		Status:    "active",
+		Note:      req.Note,
+		CreatedBy: &operatorID,
+	}
+
+	err = s.allowanceRepo.Create(allowance)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.allowanceRepo.GetByID(allowance.ID)
+}
+
+// ListAllowances lists allowances, optionally for one manager
+func (s *ManagerAllowanceService) ListAllowances(managerID *uint) ([]models.ManagerAllowance, error) {
+	return s.allowanceRepo.List(managerID)
+}
+
+// SetAllowanceStatus sets an allowance's status (active/inactive)
+// Deactivating an allowance stops further grants; points already granted are kept.
+func (s *ManagerAllowanceService) SetAllowanceStatus(allowanceID uint, status string) error {
+	if status != "active" && status != "inactive" {
+		return errors.New("invalid status: must be 'active' or 'inactive'")
+	}
+
+	if _, err := s.allowanceRepo.GetByID(allowanceID); err != nil {
+		return err
+	}
+
+	return s.allowanceRepo.UpdateStatus(allowanceID, status)
+}
+
+// GetDirectReports lists the users whose manager is managerID
+func (s *ManagerAllowanceService) GetDirectReports(managerID uint) ([]models.User, error) {
+	return s.userRepo.GetByManagerID(managerID)
+}
+
+// GrantToDirectReport grants points from the manager's allowance to one of their direct reports
+// The grant is drawn from a single usable allowance (the one ending soonest with enough left)
+// and goes through the same ledger logic as an admin grant, with the manager as operator.
+func (s *ManagerAllowanceService) GrantToDirectReport(managerID uint, userID uint, amount int, reasonCode string, reason string) (*models.PointsTransaction, error) {
+	if amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	reasonCodeID, reason, err := resolveReason(s.pointsService.reasonCodeRepo, reasonCode, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == managerID {
+		return nil, errors.New("cannot grant points to yourself")
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.ManagerID == nil || *user.ManagerID != managerID {
+		return nil, errors.New("user is not your direct report")
+	}
+
+	if !user.IsActive {
+		return nil, errors.New("cannot grant points to inactive user")
+	}
+
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// Lock the manager's allowances so concurrent grants cannot overspend them
+	allowances, err := s.allowanceRepo.GetUsableForManagerWithLock(tx, managerID, time.Now())
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	var allowance *models.ManagerAllowance
+	for i := range allowances {
+		if allowances[i].Remaining() >= amount {
+			allowance = &allowances[i]
+			break
+		}
+	}
+	if allowance == nil {
+		tx.Rollback()
+		return nil, errors.New("insufficient manager allowance")
+	}
+
+	transaction, err := s.pointsService.grantPointsInTx(tx, userID, allowance.Wallet, amount, reasonCodeID, reason, managerID, &allowance.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = s.allowanceRepo.AddUsed(tx, allowance.ID, amount)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return transaction, nil
+}
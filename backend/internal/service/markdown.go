@@ -0,0 +1,35 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+)
+
+// htmlTagPattern matches raw HTML tags and comments embedded in Markdown
+var htmlTagPattern = regexp.MustCompile(`(?s)<!--.*?-->|</?[a-zA-Z][^>]*>`)
+
+// unsafeInlineLinkPattern matches inline links and images whose target runs script or embeds data
+// One level of parentheses inside the target is allowed, as in javascript:alert(1).
+var unsafeInlineLinkPattern = regexp.MustCompile(`(?i)\]\(\s*<?\s*(javascript|vbscript|data):(?:[^()]|\([^()]*\))*\)`)
+
+// unsafeReferenceLinkPattern matches reference link definitions with the same kind of target
+var unsafeReferenceLinkPattern = regexp.MustCompile(`(?im)^(\s*\[[^\]]+\]:\s*)<?\s*(javascript|vbscript|data):\S*`)
+
+// sanitizeMarkdown strips raw HTML and script links so clients can render the Markdown as-is
+// Stripping repeats until nothing changes, since removing one tag can join the text around it
+// into a new one (e.g. "<<script>script>"). Any "<" left over is then escaped, so no markup
+// survives even where the patterns miss it.
+func sanitizeMarkdown(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for {
+		stripped := htmlTagPattern.ReplaceAllString(text, "")
+		stripped = unsafeInlineLinkPattern.ReplaceAllString(stripped, "](#)")
+		stripped = unsafeReferenceLinkPattern.ReplaceAllString(stripped, "${1}#")
+		if stripped == text {
+			break
+		}
+		text = stripped
+	}
+	text = strings.ReplaceAll(text, "<", "&lt;")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,65 @@
+package service
+
+import "testing"
+
+func TestSanitizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain markdown is kept",
+			input: "**Bold** and [a link](https://example.com)\n\n> quoted",
+			want:  "**Bold** and [a link](https://example.com)\n\n> quoted",
+		},
+		{
+			name:  "script tag is removed",
+			input: "before<script>alert(1)</script>after",
+			want:  "beforealert(1)after",
+		},
+		{
+			name:  "nested script tag does not rebuild itself",
+			input: "<<script>script>alert(1)<</script>/script>",
+			want:  "alert(1)",
+		},
+		{
+			name:  "nested img tag does not rebuild itself",
+			input: "<<img>img src=x onerror=alert(1)>",
+			want:  "",
+		},
+		{
+			name:  "html comment is removed",
+			input: "a<!-- hidden -->b",
+			want:  "ab",
+		},
+		{
+			name:  "leftover angle bracket is escaped",
+			input: "1 < 2 and <3",
+			want:  "1 &lt; 2 and &lt;3",
+		},
+		{
+			name:  "javascript inline link is neutralized",
+			input: "[click](javascript:alert(1))",
+			want:  "[click](#)",
+		},
+		{
+			name:  "javascript link split by a tag does not rebuild itself",
+			input: "[click](java<b>script:alert(1))",
+			want:  "[click](#)",
+		},
+		{
+			name:  "javascript reference link is neutralized",
+			input: "[ref]: javascript:alert(1)",
+			want:  "[ref]: #",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sanitizeMarkdown(tt.input); got != tt.want {
+				t.Errorf("sanitizeMarkdown(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
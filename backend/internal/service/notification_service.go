@@ -0,0 +1,108 @@
+package service
+
+import (
+	"awsome-shop/internal/events"
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"fmt"
+	"log"
+	"time"
+)
+
+// NotificationService delivers in-app notifications raised by domain events
+type NotificationService struct {
+	notificationRepo *repository.NotificationRepository
+	userRepo         *repository.UserRepository
+}
+
+// NewNotificationService creates a new NotificationService instance and subscribes it to the
+// events it turns into notifications
+func NewNotificationService(
+	notificationRepo *repository.NotificationRepository,
+	userRepo *repository.UserRepository,
+	eventBus *events.Bus,
+) *NotificationService {
+	s := &NotificationService{
+		notificationRepo: notificationRepo,
+		userRepo:         userRepo,
+	}
+
+	eventBus.Subscribe(events.ProductLowStock, s.notifyLowStock)
+
+	return s
+}
+
+// notifyLowStock notifies every active admin that a product is running low
+func (s *NotificationService) notifyLowStock(event events.Event) {
+	payload, ok := event.Payload.(events.ProductLowStockPayload)
+	if !ok {
+		return
+	}
+
+	admins, err := s.userRepo.GetActiveAdmins()
+	if err != nil {
+		log.Printf("Failed to load admins for low-stock notification: %v", err)
+		return
+	}
+
+	notifications := make([]models.Notification, 0, len(admins))
+	for _, admin := range admins {
+		notification := models.Notification{
+			UserID:    admin.ID,
+			Type:      models.NotificationLowStock,
+			ProductID: &payload.ProductID,
+		}
+		if admin.PreferredLanguage == models.LanguageEn {
+			notification.Title = fmt.Sprintf("Low stock: %s", payload.ProductName)
+			notification.Body = fmt.Sprintf("%d left in stock (alert threshold %d)", payload.StockQuantity, payload.Threshold)
+		} else {
+			notification.Title = fmt.Sprintf("库存不足：%s", payload.ProductName)
+			notification.Body = fmt.Sprintf("剩余库存 %d（预警阈值 %d）", payload.StockQuantity, payload.Threshold)
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err := s.notificationRepo.CreateBatch(notifications); err != nil {
+		log.Printf("Failed to create low-stock notifications for product %d: %v", payload.ProductID, err)
+	}
+}
+
+// NotificationPage is one page of a user's notifications
+type NotificationPage struct {
+	Notifications []models.Notification `json:"notifications"`
+	Total         int64                 `json:"total"`
+	UnreadCount   int64                 `json:"unread_count"`
+	Page          int                   `json:"page"`
+	PageSize      int                   `json:"page_size"`
+}
+
+// ListNotifications retrieves a page of a user's notifications with their unread count
+func (s *NotificationService) ListNotifications(userID uint, unreadOnly bool, page, pageSize int) (*NotificationPage, error) {
+	notifications, total, err := s.notificationRepo.ListByUser(userID, unreadOnly, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NotificationPage{
+		Notifications: notifications,
+		Total:         total,
+		UnreadCount:   unread,
+		Page:          page,
+		PageSize:      pageSize,
+	}, nil
+}
+
+// MarkRead marks one of a user's notifications as read
+func (s *NotificationService) MarkRead(userID, notificationID uint) error {
+	return s.notificationRepo.MarkRead(userID, notificationID, time.Now())
+}
+
+// MarkAllRead marks all of a user's notifications as read
+func (s *NotificationService) MarkAllRead(userID uint) (int64, error) {
+	return s.notificationRepo.MarkAllRead(userID, time.Now())
+}
@@ -3,10 +3,13 @@ package service
 import (
 	"awsome-shop/internal/models"
 	"awsome-shop/internal/repository"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -15,6 +18,9 @@ import (
 type PointsService struct {
 	userRepo              *repository.UserRepository
 	pointsTransactionRepo *repository.PointsTransactionRepository
+	walletRepo            *repository.UserWalletRepository
+	approvalRepo          *repository.PointsGrantApprovalRepository
+	reasonCodeRepo        *repository.ReasonCodeRepository
 	db                    *gorm.DB
 }
 
@@ -22,46 +28,86 @@ type PointsService struct {
 func NewPointsService(
 	userRepo *repository.UserRepository,
 	pointsTransactionRepo *repository.PointsTransactionRepository,
+	walletRepo *repository.UserWalletRepository,
+	approvalRepo *repository.PointsGrantApprovalRepository,
+	reasonCodeRepo *repository.ReasonCodeRepository,
 	db *gorm.DB,
 ) *PointsService {
 	return &PointsService{
 		userRepo:              userRepo,
 		pointsTransactionRepo: pointsTransactionRepo,
+		walletRepo:            walletRepo,
+		approvalRepo:          approvalRepo,
+		reasonCodeRepo:        reasonCodeRepo,
 		db:                    db,
 	}
 }
 
 // GrantPointsRequest represents a request to grant points
 type GrantPointsRequest struct {
-	UserID uint   `json:"user_id" binding:"required"`
-	Amount int    `json:"amount" binding:"required,min=1"`
-	Reason string `json:"reason" binding:"required"`
+	UserID     uint   `json:"user_id" binding:"required"`
+	Wallet     string `json:"wallet"`
+	Amount     int    `json:"amount" binding:"required,min=1"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"`
 }
 
-// GrantPoints grants points to a user
-func (s *PointsService) GrantPoints(userID uint, amount int, reason string, operatorID uint) error {
+// GrantPoints grants points to a user's wallet (the benefit wallet when wallet is empty)
+// The reason is a reason code, a free-text note, or both (see resolveReason). Operators
+// cannot grant to themselves. A grant to an admin is not applied; it is held as a pending
+// approval for a second admin, and that approval is returned instead.
+func (s *PointsService) GrantPoints(userID uint, wallet string, amount int, reasonCode string, reason string, operatorID uint) (*models.PointsGrantApproval, error) {
 	if amount <= 0 {
-		return errors.New("amount must be greater than 0")
+		return nil, errors.New("amount must be greater than 0")
 	}
 
-	if reason == "" {
-		return errors.New("reason is required")
+	reasonCodeID, reason, err := resolveReason(s.reasonCodeRepo, reasonCode, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if userID == operatorID {
+		return nil, errors.New("cannot grant points to yourself")
+	}
+
+	wallet, err = normalizeWallet(wallet)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get user
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !user.IsActive {
-		return errors.New("cannot grant points to inactive user")
+		return nil, errors.New("cannot grant points to inactive user")
+	}
+
+	if user.Role == "admin" {
+		approval := &models.PointsGrantApproval{
+			UserID:       userID,
+			Wallet:       wallet,
+			Amount:       amount,
+			Reason:       reason,
+			ReasonCodeID: reasonCodeID,
+			RequestedBy:  operatorID,
+			Status:       "pending",
+		}
+
+		err = s.approvalRepo.Create(approval)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.approvalRepo.GetByID(approval.ID)
 	}
 
 	// Start transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
-		return tx.Error
+		return nil, tx.Error
 	}
 
 	defer func() {
@@ -70,70 +116,103 @@ func (s *PointsService) GrantPoints(userID uint, amount int, reason string, oper
 		}
 	}()
 
+	_, err = s.grantPointsInTx(tx, userID, wallet, amount, reasonCodeID, reason, operatorID, nil)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// grantPointsInTx credits a user's wallet and writes the grant transaction inside tx
+// The user row is locked so the total and wallet balances change together. allowanceID
+// records the manager allowance a grant was drawn from, if any.
+func (s *PointsService) grantPointsInTx(tx *gorm.DB, userID uint, wallet string, amount int, reasonCodeID *uint, reason string, operatorID uint, allowanceID *uint) (*models.PointsTransaction, error) {
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, []uint{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(lockedUsers) == 0 {
+		return nil, errors.New("user not found")
+	}
+
 	// Calculate new balance
-	newBalance := user.PointsBalance + amount
+	newBalance := lockedUsers[0].PointsBalance + amount
 
 	// Update user points balance
 	err = tx.Model(&models.User{}).
 		Where("id = ?", userID).
 		Update("points_balance", newBalance).Error
 	if err != nil {
-		tx.Rollback()
-		return err
+		return nil, err
+	}
+
+	err = s.walletRepo.AdjustBalance(tx, userID, wallet, amount)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create transaction record
 	transaction := &models.PointsTransaction{
 		UserID:          userID,
 		TransactionType: "grant",
+		Wallet:          wallet,
 		Amount:          amount,
 		BalanceAfter:    newBalance,
 		Reason:          reason,
+		ReasonCodeID:    reasonCodeID,
 		OperatorID:      &operatorID,
 		RelatedOrderID:  nil,
+		AllowanceID:     allowanceID,
 	}
 
 	err = tx.Create(transaction).Error
 	if err != nil {
-		tx.Rollback()
-		return err
+		return nil, err
 	}
 
-	// Commit transaction
-	err = tx.Commit().Error
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return transaction, nil
 }
 
 // DeductPointsRequest represents a request to deduct points
 type DeductPointsRequest struct {
-	UserID uint   `json:"user_id" binding:"required"`
-	Amount int    `json:"amount" binding:"required,min=1"`
-	Reason string `json:"reason" binding:"required"`
+	UserID     uint   `json:"user_id" binding:"required"`
+	Wallet     string `json:"wallet"`
+	Amount     int    `json:"amount" binding:"required,min=1"`
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"`
 }
 
-// DeductPoints deducts points from a user
-func (s *PointsService) DeductPoints(userID uint, amount int, reason string, operatorID uint) error {
+// DeductPoints deducts points from a user's wallet (the benefit wallet when wallet is empty)
+func (s *PointsService) DeductPoints(userID uint, wallet string, amount int, reasonCode string, reason string, operatorID uint) error {
 	if amount <= 0 {
 		return errors.New("amount must be greater than 0")
 	}
 
-	if reason == "" {
-		return errors.New("reason is required")
+	reasonCodeID, reason, err := resolveReason(s.reasonCodeRepo, reasonCode, reason)
+	if err != nil {
+		return err
 	}
 
-	// Get user
-	user, err := s.userRepo.GetByID(userID)
+	if userID == operatorID {
+		return errors.New("cannot deduct points from yourself")
+	}
+
+	wallet, err = normalizeWallet(wallet)
 	if err != nil {
 		return err
 	}
 
-	// Check if user has sufficient balance
-	if user.PointsBalance < amount {
-		return errors.New("insufficient points balance")
+	// Get user
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return err
 	}
 
 	// Start transaction
@@ -148,8 +227,31 @@ func (s *PointsService) DeductPoints(userID uint, amount int, reason string, ope
 		}
 	}()
 
+	// Lock the user so the balance check cannot race with redemptions
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(lockedUsers) == 0 {
+		tx.Rollback()
+		return errors.New("user not found")
+	}
+
+	walletBalances, err := s.walletRepo.GetBalances(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// Check if the wallet has sufficient balance
+	if walletBalances[userID][wallet] < amount {
+		tx.Rollback()
+		return fmt.Errorf("insufficient points balance in %s wallet", wallet)
+	}
+
 	// Calculate new balance
-	newBalance := user.PointsBalance - amount
+	newBalance := lockedUsers[0].PointsBalance - amount
 
 	// Update user points balance
 	err = tx.Model(&models.User{}).
@@ -160,13 +262,21 @@ func (s *PointsService) DeductPoints(userID uint, amount int, reason string, ope
 		return err
 	}
 
+	err = s.walletRepo.AdjustBalance(tx, userID, wallet, -amount)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	// Create transaction record (negative amount for deduction)
 	transaction := &models.PointsTransaction{
 		UserID:          userID,
 		TransactionType: "deduct",
+		Wallet:          wallet,
 		Amount:          -amount,
 		BalanceAfter:    newBalance,
 		Reason:          reason,
+		ReasonCodeID:    reasonCodeID,
 		OperatorID:      &operatorID,
 		RelatedOrderID:  nil,
 	}
@@ -186,6 +296,130 @@ func (s *PointsService) DeductPoints(userID uint, amount int, reason string, ope
 	return nil
 }
 
+// ReverseTransaction writes an equal-and-opposite entry that references the original transaction
+// An entry can be reversed only once; reversals, redemptions and ledger repair adjustments cannot
+// be reversed, and the reversal fails if it would leave the user with a negative balance.
+// Operators cannot reverse entries on their own account.
+func (s *PointsService) ReverseTransaction(transactionID uint, reason string, operatorID uint) (*models.PointsTransaction, error) {
+	if reason == "" {
+		return nil, errors.New("reason is required")
+	}
+
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	original, err := s.pointsTransactionRepo.GetByIDWithLock(tx, transactionID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if original.UserID == operatorID {
+		tx.Rollback()
+		return nil, errors.New("cannot reverse transactions on your own account")
+	}
+
+	switch original.TransactionType {
+	case "reversal":
+		tx.Rollback()
+		return nil, errors.New("a reversal cannot itself be reversed")
+	case "redemption":
+		tx.Rollback()
+		return nil, errors.New("redemption transactions cannot be reversed")
+	case "adjustment":
+		// Repair corrections only bring the ledger in line with the stored balance; reversing
+		// one would move real balance and recreate the mismatch it fixed
+		tx.Rollback()
+		return nil, errors.New("ledger repair adjustments cannot be reversed")
+	}
+
+	alreadyReversed, err := s.pointsTransactionRepo.ExistsReversalOf(tx, original.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if alreadyReversed {
+		tx.Rollback()
+		return nil, errors.New("transaction has already been reversed")
+	}
+
+	// Lock the user so the balance check cannot race with redemptions
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, []uint{original.UserID})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(lockedUsers) == 0 {
+		tx.Rollback()
+		return nil, errors.New("user not found")
+	}
+	user := lockedUsers[0]
+
+	walletBalances, err := s.walletRepo.GetBalances(tx, []uint{user.ID})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Calculate new balance; the reversal comes out of the original entry's wallet
+	newBalance := user.PointsBalance - original.Amount
+	if newBalance < 0 || walletBalances[user.ID][original.Wallet] < original.Amount {
+		tx.Rollback()
+		return nil, errors.New("insufficient points balance to reverse this transaction")
+	}
+
+	// Update user points balance
+	err = tx.Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Update("points_balance", newBalance).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = s.walletRepo.AdjustBalance(tx, user.ID, original.Wallet, -original.Amount)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Create reversal transaction record
+	reversal := &models.PointsTransaction{
+		UserID:          user.ID,
+		TransactionType: "reversal",
+		Wallet:          original.Wallet,
+		Amount:          -original.Amount,
+		BalanceAfter:    newBalance,
+		Reason:          reason,
+		OperatorID:      &operatorID,
+		RelatedOrderID:  nil,
+		ReversalOfID:    &original.ID,
+	}
+
+	err = tx.Create(reversal).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reversal, nil
+}
+
 // GetPointsBalance gets a user's current points balance
 func (s *PointsService) GetPointsBalance(userID uint) (int, error) {
 	user, err := s.userRepo.GetByID(userID)
@@ -196,6 +430,26 @@ func (s *PointsService) GetPointsBalance(userID uint) (int, error) {
 	return user.PointsBalance, nil
 }
 
+// GetWalletBalances gets a user's balance in every wallet type, including empty wallets
+func (s *PointsService) GetWalletBalances(userID uint) ([]WalletBalance, error) {
+	wallets, err := s.walletRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]int, len(wallets))
+	for _, wallet := range wallets {
+		stored[wallet.Wallet] = wallet.Balance
+	}
+
+	balances := make([]WalletBalance, 0, len(models.WalletTypes))
+	for _, walletType := range models.WalletTypes {
+		balances = append(balances, WalletBalance{Wallet: walletType, Balance: stored[walletType]})
+	}
+
+	return balances, nil
+}
+
 // GetPointsHistory gets a user's points transaction history with pagination
 func (s *PointsService) GetPointsHistory(userID uint, page, pageSize int) ([]models.PointsTransaction, int64, error) {
 	if page <= 0 {
@@ -208,28 +462,162 @@ func (s *PointsService) GetPointsHistory(userID uint, page, pageSize int) ([]mod
 	return s.pointsTransactionRepo.GetByUserIDWithPagination(userID, page, pageSize)
 }
 
+// PointsHistoryQuery holds the filters and cursor for a points history search
+type PointsHistoryQuery struct {
+	Types     []string
+	Wallet    string
+	From      *time.Time // Inclusive
+	To        *time.Time // Exclusive
+	MinAmount *int       // Compared against the absolute amount
+	MaxAmount *int       // Compared against the absolute amount
+	Reason    string     // Substring match on the reason text
+	Cursor    string     // Opaque cursor returned as NextCursor by the previous page
+	Limit     int
+}
+
+// PointsHistoryPage is one page of a cursor-paginated points history search
+type PointsHistoryPage struct {
+	Transactions []models.PointsTransaction `json:"transactions"`
+	NextCursor   string                     `json:"next_cursor,omitempty"`
+	HasMore      bool                       `json:"has_more"`
+}
+
+// validTransactionTypes lists the values accepted by the transaction_type column
+var validTransactionTypes = map[string]bool{
+	"grant":      true,
+	"deduct":     true,
+	"redemption": true,
+	"adjustment": true,
+	"reversal":   true,
+}
+
+// SearchPointsHistory searches the points ledger newest first using cursor pagination
+// A nil userID searches across all users (admin ledger view)
+func (s *PointsService) SearchPointsHistory(userID *uint, query PointsHistoryQuery) (*PointsHistoryPage, error) {
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20 // Default page size
+	}
+
+	for _, transactionType := range query.Types {
+		if !validTransactionTypes[transactionType] {
+			return nil, fmt.Errorf("invalid transaction type: %s", transactionType)
+		}
+	}
+
+	if query.Wallet != "" {
+		if _, err := normalizeWallet(query.Wallet); err != nil {
+			return nil, err
+		}
+	}
+
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	if query.MinAmount != nil && query.MaxAmount != nil && *query.MinAmount > *query.MaxAmount {
+		return nil, errors.New("min_amount cannot be greater than max_amount")
+	}
+
+	filter := repository.PointsTransactionFilter{
+		UserID:         userID,
+		Types:          query.Types,
+		Wallet:         query.Wallet,
+		From:           query.From,
+		To:             query.To,
+		MinAmount:      query.MinAmount,
+		MaxAmount:      query.MaxAmount,
+		ReasonContains: query.Reason,
+		Limit:          limit + 1, // Fetch one extra row to know whether another page exists
+	}
+
+	if query.Cursor != "" {
+		beforeID, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, errors.New("invalid cursor")
+		}
+		filter.BeforeID = beforeID
+	}
+
+	transactions, err := s.pointsTransactionRepo.ListWithFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &PointsHistoryPage{Transactions: transactions}
+	if len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		page.HasMore = true
+		page.NextCursor = encodeCursor(page.Transactions[limit-1].ID)
+	}
+
+	if page.Transactions == nil {
+		page.Transactions = []models.PointsTransaction{}
+	}
+
+	return page, nil
+}
+
+// encodeCursor encodes a keyset position as an opaque cursor string
+func encodeCursor(id uint) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(uint64(id), 10)))
+}
+
+// decodeCursor decodes a cursor produced by encodeCursor
+func decodeCursor(cursor string) (uint, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.ParseUint(string(raw), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(id), nil
+}
+
 // BatchGrantEntry represents a single entry in batch grant operation
 type BatchGrantEntry struct {
-	Email  string
-	Name   string
-	Amount int
-	Reason string
+	Row          int
+	Email        string
+	Name         string
+	Amount       int
+	Reason       string
+	ReasonCode   string
+	ReasonCodeID *uint // Set once the row's reason has been resolved
 }
 
 // ParseBatchGrantMarkdown parses markdown table for batch points grant
-// Expected format:
-// | 员工邮箱 | 姓名 | 积分 | 备注 |
-// |---------|------|------|------|
-// | email   | name | 100  | note |
+// Expected format (the reason code column is optional):
+// | 员工邮箱 | 姓名 | 积分 | 备注 | 原因代码 |
+// |---------|------|------|------|---------|
+// | email   | name | 100  | note | Q3_BONUS |
 func (s *PointsService) ParseBatchGrantMarkdown(markdown string) ([]BatchGrantEntry, error) {
+	entries, rowErrors, err := parseBatchGrantRows(markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, fmt.Errorf("invalid row %d: %s", rowErrors[0].Row, rowErrors[0].Message)
+	}
+
+	return entries, nil
+}
+
+// parseBatchGrantRows parses a batch grant table, collecting malformed rows instead of stopping at the first one
+func parseBatchGrantRows(markdown string) ([]BatchGrantEntry, []BatchRowError, error) {
 	lines := strings.Split(strings.TrimSpace(markdown), "\n")
-	
+
 	if len(lines) < 3 {
-		return nil, errors.New("invalid markdown table: must have header, separator, and at least one data row")
+		return nil, nil, errors.New("invalid markdown table: must have header, separator, and at least one data row")
 	}
 
 	var entries []BatchGrantEntry
-	
+	var rowErrors []BatchRowError
+
 	// Skip header (line 0) and separator (line 1)
 	for i := 2; i < len(lines); i++ {
 		line := strings.TrimSpace(lines[i])
@@ -240,9 +628,13 @@ func (s *PointsService) ParseBatchGrantMarkdown(markdown string) ([]BatchGrantEn
 		// Parse table row
 		line = strings.Trim(line, "|")
 		fields := strings.Split(line, "|")
-		
+
 		if len(fields) < 4 {
-			return nil, fmt.Errorf("invalid row %d: expected 4 columns (email, name, amount, reason)", i-1)
+			rowErrors = append(rowErrors, BatchRowError{
+				Row:     i - 1,
+				Message: "expected 4 columns (email, name, amount, reason)",
+			})
+			continue
 		}
 
 		// Trim whitespace
@@ -253,65 +645,318 @@ func (s *PointsService) ParseBatchGrantMarkdown(markdown string) ([]BatchGrantEn
 		// Parse amount
 		amount, err := strconv.Atoi(fields[2])
 		if err != nil {
-			return nil, fmt.Errorf("invalid amount in row %d: %s", i-1, fields[2])
+			rowErrors = append(rowErrors, BatchRowError{
+				Row:     i - 1,
+				Email:   fields[0],
+				Message: fmt.Sprintf("invalid amount: %s", fields[2]),
+			})
+			continue
 		}
 
 		entry := BatchGrantEntry{
+			Row:    i - 1,
 			Email:  fields[0],
 			Name:   fields[1],
 			Amount: amount,
 			Reason: fields[3],
 		}
+		if len(fields) >= 5 {
+			entry.ReasonCode = fields[4]
+		}
 
 		entries = append(entries, entry)
 	}
 
-	if len(entries) == 0 {
-		return nil, errors.New("no valid entries found in markdown table")
+	if len(entries) == 0 && len(rowErrors) == 0 {
+		return nil, nil, errors.New("no valid entries found in markdown table")
 	}
 
-	return entries, nil
+	return entries, rowErrors, nil
 }
 
-// BatchGrantPoints grants points to multiple users
-func (s *PointsService) BatchGrantPoints(markdown string, operatorID uint) error {
+// validateBatchEntry checks a single batch row, resolves its reason and resolves its user
+// Users are cached in userMap so repeated emails are looked up once
+func (s *PointsService) validateBatchEntry(entry *BatchGrantEntry, userMap map[string]*models.User, requireActive bool) (*models.User, string) {
+	if entry.Email == "" {
+		return nil, "email is required"
+	}
+	if entry.Amount <= 0 {
+		return nil, "amount must be greater than 0"
+	}
+
+	reasonCodeID, reason, err := resolveReason(s.reasonCodeRepo, entry.ReasonCode, entry.Reason)
+	if err != nil {
+		return nil, err.Error()
+	}
+	entry.ReasonCodeID = reasonCodeID
+	entry.Reason = reason
+
+	user, ok := userMap[entry.Email]
+	if !ok {
+		var err error
+		user, err = s.userRepo.GetByEmail(entry.Email)
+		if err != nil {
+			return nil, "user not found"
+		}
+		userMap[entry.Email] = user
+	}
+
+	if requireActive && !user.IsActive {
+		return nil, "user is inactive"
+	}
+
+	// Verify name matches (optional check)
+	if entry.Name != "" && entry.Name != user.FullName {
+		return nil, fmt.Sprintf("name mismatch (expected: %s, got: %s)", user.FullName, entry.Name)
+	}
+
+	return user, ""
+}
+
+// BatchGrantRowResult reports the outcome of a single row in a batch grant
+type BatchGrantRowResult struct {
+	Row           int    `json:"row"`
+	Email         string `json:"email"`
+	Name          string `json:"name"`
+	Amount        int    `json:"amount"`
+	BalanceBefore int    `json:"balance_before"`
+	BalanceAfter  int    `json:"balance_after"`
+	Status        string `json:"status"` // valid, applied, failed
+	Error         string `json:"error,omitempty"`
+}
+
+// BatchGrantResult summarizes a batch grant, including dry runs
+type BatchGrantResult struct {
+	DryRun      bool                  `json:"dry_run"`
+	Partial     bool                  `json:"partial"`
+	Total       int                   `json:"total"`
+	Succeeded   int                   `json:"succeeded"`
+	Failed      int                   `json:"failed"`
+	TotalAmount int                   `json:"total_amount"`
+	Rows        []BatchGrantRowResult `json:"rows"`
+}
+
+// BatchGrantPoints grants points to multiple users in one wallet
+// With DryRun set, every row is validated and the resulting balances are returned without
+// writing anything. With Partial set, valid rows are applied and failed rows are reported;
+// otherwise any failed row rejects the whole batch.
+func (s *PointsService) BatchGrantPoints(markdown string, wallet string, operatorID uint, opts BatchOptions) (*BatchGrantResult, error) {
+	wallet, err := normalizeWallet(wallet)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse markdown table
-	entries, err := s.ParseBatchGrantMarkdown(markdown)
+	entries, parseErrors, err := parseBatchGrantRows(markdown)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	result := &BatchGrantResult{DryRun: opts.DryRun, Partial: opts.Partial}
+	for _, rowErr := range parseErrors {
+		result.Rows = append(result.Rows, BatchGrantRowResult{
+			Row:    rowErr.Row,
+			Email:  rowErr.Email,
+			Status: "failed",
+			Error:  rowErr.Message,
+		})
 	}
 
-	// Validate all entries first
+	// Validate all entries first, previewing balances cumulatively per user
 	userMap := make(map[string]*models.User)
-	
-	for i, entry := range entries {
-		if entry.Email == "" {
-			return fmt.Errorf("entry %d: email is required", i+1)
+	balances := make(map[uint]int)
+	var validEntries []BatchGrantEntry
+
+	for i := range entries {
+		entry := &entries[i]
+		rowResult := BatchGrantRowResult{
+			Row:    entry.Row,
+			Email:  entry.Email,
+			Name:   entry.Name,
+			Amount: entry.Amount,
+		}
+
+		user, msg := s.validateBatchEntry(entry, userMap, true)
+		if msg == "" {
+			if user.ID == operatorID {
+				msg = "cannot grant points to yourself"
+			} else if user.Role == "admin" {
+				msg = "grants to admins need a second approver; use the single grant endpoint"
+			}
 		}
-		if entry.Amount <= 0 {
-			return fmt.Errorf("entry %d: amount must be greater than 0", i+1)
+		if msg != "" {
+			rowResult.Status = "failed"
+			rowResult.Error = msg
+			result.Rows = append(result.Rows, rowResult)
+			continue
+		}
+
+		if _, ok := balances[user.ID]; !ok {
+			balances[user.ID] = user.PointsBalance
+		}
+		rowResult.BalanceBefore = balances[user.ID]
+		rowResult.BalanceAfter = rowResult.BalanceBefore + entry.Amount
+		rowResult.Status = "valid"
+		balances[user.ID] = rowResult.BalanceAfter
+
+		result.Rows = append(result.Rows, rowResult)
+		validEntries = append(validEntries, *entry)
+	}
+
+	sort.Slice(result.Rows, func(i, j int) bool {
+		return result.Rows[i].Row < result.Rows[j].Row
+	})
+
+	var rowErrors []BatchRowError
+	for _, row := range result.Rows {
+		result.Total++
+		if row.Status == "failed" {
+			result.Failed++
+			rowErrors = append(rowErrors, BatchRowError{Row: row.Row, Email: row.Email, Message: row.Error})
+			continue
 		}
-		if entry.Reason == "" {
-			return fmt.Errorf("entry %d: reason is required", i+1)
+		result.Succeeded++
+		result.TotalAmount += row.Amount
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if len(rowErrors) > 0 && !opts.Partial {
+		return nil, &BatchValidationError{Rows: rowErrors}
+	}
+
+	if len(validEntries) == 0 {
+		return result, nil
+	}
+
+	userIDs := make([]uint, 0, len(userMap))
+	for _, entry := range validEntries {
+		userIDs = append(userIDs, userMap[entry.Email].ID)
+	}
+
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
 		}
+	}()
 
-		// Get user by email
-		user, err := s.userRepo.GetByEmail(entry.Email)
+	// Lock all affected users so balances cannot change underneath the batch
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, userIDs)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	for _, user := range lockedUsers {
+		balances[user.ID] = user.PointsBalance
+	}
+
+	rowIndex := make(map[int]int, len(result.Rows))
+	for i, row := range result.Rows {
+		rowIndex[row.Row] = i
+	}
+
+	// Process all valid entries
+	for _, entry := range validEntries {
+		userID := userMap[entry.Email].ID
+		newBalance := balances[userID] + entry.Amount
+
+		// Update user points balance
+		err = tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Update("points_balance", newBalance).Error
 		if err != nil {
-			return fmt.Errorf("entry %d: user with email %s not found", i+1, entry.Email)
+			tx.Rollback()
+			return nil, err
 		}
 
-		if !user.IsActive {
-			return fmt.Errorf("entry %d: user %s is inactive", i+1, entry.Email)
+		err = s.walletRepo.AdjustBalance(tx, userID, wallet, entry.Amount)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
 		}
 
-		// Verify name matches (optional check)
-		if entry.Name != "" && entry.Name != user.FullName {
-			return fmt.Errorf("entry %d: name mismatch for %s (expected: %s, got: %s)", 
-				i+1, entry.Email, user.FullName, entry.Name)
+		// Create transaction record
+		transaction := &models.PointsTransaction{
+			UserID:          userID,
+			TransactionType: "grant",
+			Wallet:          wallet,
+			Amount:          entry.Amount,
+			BalanceAfter:    newBalance,
+			Reason:          entry.Reason,
+			ReasonCodeID:    entry.ReasonCodeID,
+			OperatorID:      &operatorID,
+			RelatedOrderID:  nil,
 		}
 
-		userMap[entry.Email] = user
+		err = tx.Create(transaction).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		row := &result.Rows[rowIndex[entry.Row]]
+		row.BalanceBefore = balances[userID]
+		row.BalanceAfter = newBalance
+		row.Status = "applied"
+
+		balances[userID] = newBalance
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// BatchDeductPoints deducts points from one wallet of multiple users using the batch grant table format
+// Every row is validated before anything is written, and rows with insufficient balance
+// are reported individually. The batch runs in one transaction with the affected user
+// rows locked, so it cannot race with concurrent redemptions.
+func (s *PointsService) BatchDeductPoints(markdown string, wallet string, operatorID uint) error {
+	wallet, err := normalizeWallet(wallet)
+	if err != nil {
+		return err
+	}
+
+	// Parse markdown table
+	entries, rowErrors, err := parseBatchGrantRows(markdown)
+	if err != nil {
+		return err
+	}
+
+	// Validate all entries first, collecting every failure
+	userMap := make(map[string]*models.User)
+
+	for i := range entries {
+		entry := &entries[i]
+		user, msg := s.validateBatchEntry(entry, userMap, false)
+		if msg == "" && user.ID == operatorID {
+			msg = "cannot deduct points from yourself"
+		}
+		if msg != "" {
+			rowErrors = append(rowErrors, BatchRowError{Row: entry.Row, Email: entry.Email, Message: msg})
+		}
+	}
+
+	if len(rowErrors) > 0 {
+		return &BatchValidationError{Rows: rowErrors}
+	}
+
+	userIDs := make([]uint, 0, len(userMap))
+	for _, user := range userMap {
+		userIDs = append(userIDs, user.ID)
 	}
 
 	// Start transaction
@@ -326,27 +971,76 @@ func (s *PointsService) BatchGrantPoints(markdown string, operatorID uint) error
 		}
 	}()
 
-	// Process all entries
+	// Lock all affected users so balances cannot change underneath the batch
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, userIDs)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	walletBalances, err := s.walletRepo.GetBalances(tx, userIDs)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	balances := make(map[uint]int, len(lockedUsers))
+	for _, user := range lockedUsers {
+		balances[user.ID] = walletBalances[user.ID][wallet]
+	}
+
+	// Check wallet balances against the locked rows; a user listed more than once is charged cumulatively
+	for _, entry := range entries {
+		userID := userMap[entry.Email].ID
+		if balances[userID] < entry.Amount {
+			rowErrors = append(rowErrors, BatchRowError{
+				Row:     entry.Row,
+				Email:   entry.Email,
+				Message: fmt.Sprintf("insufficient points balance in %s wallet (available: %d, requested: %d)", wallet, balances[userID], entry.Amount),
+			})
+			continue
+		}
+		balances[userID] -= entry.Amount
+	}
+
+	if len(rowErrors) > 0 {
+		tx.Rollback()
+		return &BatchValidationError{Rows: rowErrors}
+	}
+
+	// Reset to the locked total balances and apply every entry in order
+	for _, user := range lockedUsers {
+		balances[user.ID] = user.PointsBalance
+	}
+
 	for _, entry := range entries {
-		user := userMap[entry.Email]
-		newBalance := user.PointsBalance + entry.Amount
+		userID := userMap[entry.Email].ID
+		newBalance := balances[userID] - entry.Amount
 
 		// Update user points balance
 		err = tx.Model(&models.User{}).
-			Where("id = ?", user.ID).
+			Where("id = ?", userID).
 			Update("points_balance", newBalance).Error
 		if err != nil {
 			tx.Rollback()
 			return err
 		}
 
-		// Create transaction record
+		err = s.walletRepo.AdjustBalance(tx, userID, wallet, -entry.Amount)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		// Create transaction record (negative amount for deduction)
 		transaction := &models.PointsTransaction{
-			UserID:          user.ID,
-			TransactionType: "grant",
-			Amount:          entry.Amount,
+			UserID:          userID,
+			TransactionType: "deduct",
+			Wallet:          wallet,
+			Amount:          -entry.Amount,
 			BalanceAfter:    newBalance,
 			Reason:          entry.Reason,
+			ReasonCodeID:    entry.ReasonCodeID,
 			OperatorID:      &operatorID,
 			RelatedOrderID:  nil,
 		}
@@ -356,6 +1050,8 @@ func (s *PointsService) BatchGrantPoints(markdown string, operatorID uint) error
 			tx.Rollback()
 			return err
 		}
+
+		balances[userID] = newBalance
 	}
 
 	// Commit transaction
@@ -367,9 +1063,26 @@ func (s *PointsService) BatchGrantPoints(markdown string, operatorID uint) error
 	return nil
 }
 
-// GetGrantTransactionsReport gets points grant transactions for reporting
-func (s *PointsService) GetGrantTransactionsReport() ([]repository.GrantTransactionStats, error) {
-	return s.pointsTransactionRepo.GetGrantTransactions()
+// GrantTransactionsReport lists grant transactions and their totals per reason code
+type GrantTransactionsReport struct {
+	Grants       []repository.GrantTransactionStats
+	ByReasonCode []repository.GrantReasonCodeStats
+}
+
+// GetGrantTransactionsReport gets points grant transactions and reason code totals for reporting
+// When netReversals is set, grants that were later reversed are excluded
+func (s *PointsService) GetGrantTransactionsReport(netReversals bool) (*GrantTransactionsReport, error) {
+	grants, err := s.pointsTransactionRepo.GetGrantTransactions(netReversals)
+	if err != nil {
+		return nil, err
+	}
+
+	byReasonCode, err := s.pointsTransactionRepo.GetGrantTotalsByReasonCode(netReversals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrantTransactionsReport{Grants: grants, ByReasonCode: byReasonCode}, nil
 }
 
 // GetPointsBalancesReport gets current points balances for all users
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// PriceScheduleService schedules product price changes and applies them when they fall due
+// Changes are applied and reverted through ProductService.UpdateProduct, so they are recorded
+// in the price history like any other price change, under the admin who scheduled them.
+type PriceScheduleService struct {
+	scheduleRepo   *repository.ScheduledPriceChangeRepository
+	productRepo    *repository.ProductRepository
+	productService *ProductService
+}
+
+// NewPriceScheduleService creates a new PriceScheduleService instance
+func NewPriceScheduleService(
+	scheduleRepo *repository.ScheduledPriceChangeRepository,
+	productRepo *repository.ProductRepository,
+	productService *ProductService,
+) *PriceScheduleService {
+	return &PriceScheduleService{
+		scheduleRepo:   scheduleRepo,
+		productRepo:    productRepo,
+		productService: productService,
+	}
+}
+
+// ScheduledPriceChangeRequest represents a request to schedule a price change
+type ScheduledPriceChangeRequest struct {
+	ProductID      uint
+	PointsRequired int
+	StartsAt       time.Time
+	EndsAt         *time.Time // Nil for a permanent change
+	Note           string
+}
+
+// schedulesOverlap reports whether two changes would set the product's price at the same time
+// A temporary change covers [StartsAt, EndsAt); a permanent change only its start time.
+func schedulesOverlap(a, b *models.ScheduledPriceChange) bool {
+	switch {
+	case !a.IsTemporary() && !b.IsTemporary():
+		return a.StartsAt.Equal(b.StartsAt)
+	case !a.IsTemporary():
+		return !a.StartsAt.Before(b.StartsAt) && a.StartsAt.Before(*b.EndsAt)
+	case !b.IsTemporary():
+		return schedulesOverlap(b, a)
+	default:
+		return a.StartsAt.Before(*b.EndsAt) && b.StartsAt.Before(*a.EndsAt)
+	}
+}
+
+// CreateScheduledChange schedules a product's price to change at a future time
+// A change may not overlap another pending or active change for the same product.
+func (s *PriceScheduleService) CreateScheduledChange(req *ScheduledPriceChangeRequest, operatorID uint) (*models.ScheduledPriceChange, error) {
+	if _, err := s.productRepo.GetByID(req.ProductID); err != nil {
+		return nil, err
+	}
+
+	if req.PointsRequired <= 0 {
+		return nil, errors.New("points required must be greater than 0")
+	}
+	if !req.StartsAt.After(time.Now()) {
+		return nil, errors.New("starts_at must be in the future")
+	}
+	if req.EndsAt != nil && !req.EndsAt.After(req.StartsAt) {
+		return nil, errors.New("ends_at must be after starts_at")
+	}
+
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > 500 {
+		return nil, errors.New("note cannot be longer than 500 characters")
+	}
+
+	change := &models.ScheduledPriceChange{
+		ProductID:      req.ProductID,
+		PointsRequired: req.PointsRequired,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		Status:         models.PriceChangePending,
+		Note:           note,
+		OperatorID:     operatorID,
+	}
+
+	open, err := s.scheduleRepo.ListOpenByProduct(req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range open {
+		if schedulesOverlap(change, &open[i]) {
+			return nil, fmt.Errorf("overlaps scheduled price change %d", open[i].ID)
+		}
+	}
+
+	if err := s.scheduleRepo.Create(change); err != nil {
+		return nil, err
+	}
+
+	return s.scheduleRepo.GetByID(change.ID)
+}
+
+// ListScheduledChanges lists scheduled price changes, optionally filtered by status and product
+func (s *PriceScheduleService) ListScheduledChanges(status *string, productID *uint) ([]models.ScheduledPriceChange, error) {
+	return s.scheduleRepo.List(status, productID)
+}
+
+// CancelScheduledChange cancels a price change that has not been applied yet
+func (s *PriceScheduleService) CancelScheduledChange(changeID uint) (*models.ScheduledPriceChange, error) {
+	if _, err := s.scheduleRepo.GetByID(changeID); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.scheduleRepo.Transition(changeID, models.PriceChangePending, map[string]interface{}{
+		"status":       models.PriceChangeCancelled,
+		"cancelled_at": time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, errors.New("only pending price changes can be cancelled")
+	}
+
+	return s.scheduleRepo.GetByID(changeID)
+}
+
+// ProcessDueChanges reverts temporary changes that have ended, then applies changes that have started
+// Reverting first lets one window end and the next begin at the same moment. A change that
+// fails is left for the next run with its error recorded.
+func (s *PriceScheduleService) ProcessDueChanges(now time.Time) (applied, reverted int, err error) {
+	due, err := s.scheduleRepo.ListDueToRevert(now)
+	if err != nil {
+		return 0, 0, err
+	}
+	for i := range due {
+		ok, err := s.revertChange(&due[i], now)
+		if err != nil {
+			log.Printf("Failed to revert scheduled price change %d: %v", due[i].ID, err)
+			continue
+		}
+		if ok {
+			reverted++
+		}
+	}
+
+	due, err = s.scheduleRepo.ListDueToApply(now)
+	if err != nil {
+		return applied, reverted, err
+	}
+	for i := range due {
+		ok, err := s.applyChange(&due[i], now)
+		if err != nil {
+			log.Printf("Failed to apply scheduled price change %d: %v", due[i].ID, err)
+			continue
+		}
+		if ok {
+			applied++
+		}
+	}
+
+	return applied, reverted, nil
+}
+
+// applyChange sets the product's price and records the previous price to revert to
+// It reports false when the change was already handled elsewhere or its window has passed.
+func (s *PriceScheduleService) applyChange(change *models.ScheduledPriceChange, now time.Time) (bool, error) {
+	if change.IsTemporary() && !change.EndsAt.After(now) {
+		_, err := s.scheduleRepo.Transition(change.ID, models.PriceChangePending, map[string]interface{}{
+			"status": models.PriceChangeMissed,
+		})
+		return false, err
+	}
+
+	product, err := s.productRepo.GetByID(change.ProductID)
+	if err != nil {
+		return false, s.recordError(change.ID, models.PriceChangePending, err)
+	}
+
+	status := models.PriceChangeCompleted
+	if change.IsTemporary() {
+		status = models.PriceChangeActive
+	}
+
+	claimed, err := s.scheduleRepo.Transition(change.ID, models.PriceChangePending, map[string]interface{}{
+		"status":        status,
+		"revert_points": product.PointsRequired,
+		"applied_at":    now,
+		"error":         "",
+	})
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	_, err = s.productService.UpdateProduct(change.ProductID, &UpdateProductRequest{
+		PointsRequired: &change.PointsRequired,
+	}, change.OperatorID)
+	if err != nil {
+		_, releaseErr := s.scheduleRepo.Transition(change.ID, status, map[string]interface{}{
+			"status":        models.PriceChangePending,
+			"revert_points": nil,
+			"applied_at":    nil,
+			"error":         truncateError(err),
+		})
+		if releaseErr != nil {
+			log.Printf("Failed to release scheduled price change %d: %v", change.ID, releaseErr)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// revertChange restores the price a temporary change replaced
+// If an admin has changed the price since the change was applied, their price is kept.
+func (s *PriceScheduleService) revertChange(change *models.ScheduledPriceChange, now time.Time) (bool, error) {
+	product, err := s.productRepo.GetByID(change.ProductID)
+	if err != nil {
+		return false, s.recordError(change.ID, models.PriceChangeActive, err)
+	}
+
+	updates := map[string]interface{}{
+		"status":      models.PriceChangeCompleted,
+		"reverted_at": now,
+		"error":       "",
+	}
+	shouldRevert := change.RevertPoints != nil && product.PointsRequired == change.PointsRequired
+	if !shouldRevert {
+		updates["reverted_at"] = nil
+		updates["error"] = "price was changed while the change was active; not reverted"
+	}
+
+	claimed, err := s.scheduleRepo.Transition(change.ID, models.PriceChangeActive, updates)
+	if err != nil || !claimed || !shouldRevert {
+		return false, err
+	}
+
+	_, err = s.productService.UpdateProduct(change.ProductID, &UpdateProductRequest{
+		PointsRequired: change.RevertPoints,
+	}, change.OperatorID)
+	if err != nil {
+		_, releaseErr := s.scheduleRepo.Transition(change.ID, models.PriceChangeCompleted, map[string]interface{}{
+			"status":      models.PriceChangeActive,
+			"reverted_at": nil,
+			"error":       truncateError(err),
+		})
+		if releaseErr != nil {
+			log.Printf("Failed to release scheduled price change %d: %v", change.ID, releaseErr)
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// recordError stores err on a change that is still in status, then returns err
+func (s *PriceScheduleService) recordError(changeID uint, status string, err error) error {
+	if _, updateErr := s.scheduleRepo.Transition(changeID, status, map[string]interface{}{
+		"error": truncateError(err),
+	}); updateErr != nil {
+		log.Printf("Failed to record error on scheduled price change %d: %v", changeID, updateErr)
+	}
+	return err
+}
+
+// RunScheduler applies and reverts scheduled price changes on a fixed interval
+// It blocks, so it should be started in its own goroutine
+func (s *PriceScheduleService) RunScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		applied, reverted, err := s.ProcessDueChanges(time.Now())
+		if err != nil {
+			log.Printf("Failed to process scheduled price changes: %v", err)
+			continue
+		}
+		if applied > 0 || reverted > 0 {
+			log.Printf("Applied %d and reverted %d scheduled price changes", applied, reverted)
+		}
+	}
+}
+
+// truncateError returns err's message cut to fit the 500 character error column
+func truncateError(err error) string {
+	message := []rune(err.Error())
+	if len(message) > 500 {
+		message = message[:500]
+	}
+	return string(message)
+}
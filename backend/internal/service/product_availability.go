@@ -0,0 +1,131 @@
+package service
+
+import (
+	"awsome-shop/internal/events"
+	"awsome-shop/internal/models"
+	"errors"
+	"log"
+	"time"
+)
+
+// validateAvailabilityWindow checks that a window's end comes after its start
+func validateAvailabilityWindow(from, until *time.Time) error {
+	if from != nil && until != nil && !until.After(*from) {
+		return errors.New("available_until must be after available_from")
+	}
+	return nil
+}
+
+// applyAvailabilityWindow brings a product's status in line with its window at now
+// A product whose window has not opened yet is held inactive so the availability job publishes
+// it, and announces it, when the window opens. An active product inside an open window is
+// stamped as published for that window, so the job leaves it alone if an admin later
+// deactivates it.
+func applyAvailabilityWindow(product *models.Product, now time.Time) {
+	if product.AvailableFrom == nil {
+		return
+	}
+
+	if now.Before(*product.AvailableFrom) {
+		product.Status = "inactive"
+		return
+	}
+
+	if product.Status == "active" && product.IsAvailableAt(now) {
+		product.PublishedAt = &now
+	}
+}
+
+// SetAvailability sets the window in which a product is shown in the catalog and can be redeemed
+// Either bound may be nil. The availability job activates an inactive product when its window
+// opens and deactivates it when the window closes.
+func (s *ProductService) SetAvailability(productID uint, from, until *time.Time) (*models.Product, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAvailabilityWindow(from, until); err != nil {
+		return nil, err
+	}
+
+	product.AvailableFrom = from
+	product.AvailableUntil = until
+	applyAvailabilityWindow(product, time.Now())
+
+	if err := s.productRepo.UpdateAvailability(product); err != nil {
+		return nil, err
+	}
+
+	return s.productRepo.GetByID(productID)
+}
+
+// ProcessAvailability publishes products whose window has opened and unpublishes those whose
+// window has closed, emitting an event for each
+// Each window boundary is acted on once, so an admin can still change the status by hand
+// afterwards without the job undoing it (see Product.DueToPublish).
+func (s *ProductService) ProcessAvailability(now time.Time) (published, unpublished int, err error) {
+	due, err := s.productRepo.ListDueToUnpublish(now)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, product := range due {
+		ok, err := s.productRepo.Unpublish(product.ID, now)
+		if err != nil {
+			log.Printf("Failed to unpublish product %d: %v", product.ID, err)
+			continue
+		}
+		if ok {
+			unpublished++
+			s.events.Publish(events.ProductUnpublished, events.ProductAvailabilityPayload{
+				ProductID:   product.ID,
+				ProductName: product.Name,
+				Status:      "inactive",
+			})
+		}
+	}
+
+	due, err = s.productRepo.ListDueToPublish(now)
+	if err != nil {
+		return published, unpublished, err
+	}
+	for _, product := range due {
+		if !product.DueToPublish(now) {
+			continue
+		}
+
+		ok, err := s.productRepo.Publish(product.ID, now)
+		if err != nil {
+			log.Printf("Failed to publish product %d: %v", product.ID, err)
+			continue
+		}
+		if ok {
+			published++
+			s.events.Publish(events.ProductPublished, events.ProductAvailabilityPayload{
+				ProductID:   product.ID,
+				ProductName: product.Name,
+				Status:      "active",
+			})
+		}
+	}
+
+	return published, unpublished, nil
+}
+
+// RunAvailabilityScheduler publishes and unpublishes products on a fixed interval
+// It blocks, so it should be started in its own goroutine
+func (s *ProductService) RunAvailabilityScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		published, unpublished, err := s.ProcessAvailability(time.Now())
+		if err != nil {
+			log.Printf("Failed to process product availability: %v", err)
+			continue
+		}
+		if published > 0 || unpublished > 0 {
+			log.Printf("Published %d and unpublished %d products", published, unpublished)
+		}
+	}
+}
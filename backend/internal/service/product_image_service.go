@@ -0,0 +1,290 @@
+package service
+
+import (
+	"awsome-shop/internal/config"
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"awsome-shop/internal/storage"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ProductImageService handles uploading, ordering and serving product images
+type ProductImageService struct {
+	productRepo *repository.ProductRepository
+	imageRepo   *repository.ProductImageRepository
+	storage     storage.Storage
+	cfg         config.StorageConfig
+	db          *gorm.DB
+}
+
+// NewProductImageService creates a new ProductImageService instance
+func NewProductImageService(
+	productRepo *repository.ProductRepository,
+	imageRepo *repository.ProductImageRepository,
+	fileStorage storage.Storage,
+	cfg config.StorageConfig,
+	db *gorm.DB,
+) *ProductImageService {
+	return &ProductImageService{
+		productRepo: productRepo,
+		imageRepo:   imageRepo,
+		storage:     fileStorage,
+		cfg:         cfg,
+		db:          db,
+	}
+}
+
+// MaxUploadBytes is the largest image upload accepted
+func (s *ProductImageService) MaxUploadBytes() int64 {
+	return int64(s.cfg.MaxUploadMB) << 20
+}
+
+// CacheMaxAgeSeconds is how long clients may cache a served file
+// Stored files are never overwritten, since every upload gets a fresh random key.
+func (s *ProductImageService) CacheMaxAgeSeconds() int {
+	return s.cfg.CacheMaxAgeDays * 24 * 60 * 60
+}
+
+// publicURL returns the URL a stored key is served under
+func (s *ProductImageService) publicURL(key string) string {
+	return strings.TrimSuffix(s.cfg.PublicBaseURL, "/") + "/" + key
+}
+
+// newImageKey returns a random storage key for one of a product's images
+func newImageKey(productID uint) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("products/%d/%s", productID, hex.EncodeToString(random)), nil
+}
+
+// UploadImage validates an uploaded image, stores it with a thumbnail and appends it to the product's images
+// The content type is sniffed from the file itself; a declared type that disagrees is rejected.
+func (s *ProductImageService) UploadImage(productID uint, content io.Reader, declaredType string) (*models.ProductImage, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, s.MaxUploadBytes()+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > s.MaxUploadBytes() {
+		return nil, fmt.Errorf("image cannot be larger than %d MB", s.cfg.MaxUploadMB)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("file is empty")
+	}
+
+	contentType := http.DetectContentType(data)
+	format, ok := imageFormats[contentType]
+	if !ok {
+		return nil, errors.New("unsupported image type: use JPEG, PNG or GIF")
+	}
+	if declaredType != "" {
+		mediaType, _, err := mime.ParseMediaType(declaredType)
+		if err != nil || (mediaType != contentType && mediaType != "application/octet-stream") {
+			return nil, fmt.Errorf("file content is %s, not %s", contentType, declaredType)
+		}
+	}
+
+	img, err := decodeImage(data, s.cfg.MaxImagePixels)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, thumbnailType, thumbnailExt, err := encodeThumbnail(resizeToFit(img, s.cfg.ThumbnailSize), format.format)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := newImageKey(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	image := &models.ProductImage{
+		ProductID:    productID,
+		StorageKey:   key + format.extension,
+		ThumbnailKey: key + "_thumb" + thumbnailExt,
+		ContentType:  contentType,
+		SizeBytes:    int64(len(data)),
+		Width:        img.Bounds().Dx(),
+		Height:       img.Bounds().Dy(),
+	}
+	image.URL = s.publicURL(image.StorageKey)
+	image.ThumbnailURL = s.publicURL(image.ThumbnailKey)
+
+	// Store files first so a saved record never points at a missing file
+	if err := s.storage.Put(image.StorageKey, bytes.NewReader(data), contentType); err != nil {
+		return nil, err
+	}
+	if err := s.storage.Put(image.ThumbnailKey, bytes.NewReader(thumbnail), thumbnailType); err != nil {
+		s.deleteFiles(image)
+		return nil, err
+	}
+
+	err = s.inProductTx(productID, func(tx *gorm.DB, product *models.Product, images []models.ProductImage) ([]models.ProductImage, error) {
+		image.SortOrder = len(images)
+		if err := s.imageRepo.Create(tx, image); err != nil {
+			return nil, err
+		}
+		return append(images, *image), nil
+	})
+	if err != nil {
+		s.deleteFiles(image)
+		return nil, err
+	}
+
+	return image, nil
+}
+
+// ReorderImages sets the display order of a product's images
+// imageIDs must list every one of the product's images exactly once.
+func (s *ProductImageService) ReorderImages(productID uint, imageIDs []uint) ([]models.ProductImage, error) {
+	var ordered []models.ProductImage
+
+	err := s.inProductTx(productID, func(tx *gorm.DB, product *models.Product, images []models.ProductImage) ([]models.ProductImage, error) {
+		byID := make(map[uint]models.ProductImage, len(images))
+		for _, image := range images {
+			byID[image.ID] = image
+		}
+
+		if len(imageIDs) != len(images) {
+			return nil, errors.New("image_ids must list every image of the product exactly once")
+		}
+
+		ordered = make([]models.ProductImage, 0, len(imageIDs))
+		for i, id := range imageIDs {
+			image, ok := byID[id]
+			if !ok {
+				return nil, errors.New("image_ids must list every image of the product exactly once")
+			}
+			delete(byID, id)
+
+			if err := s.imageRepo.UpdateSortOrder(tx, id, i); err != nil {
+				return nil, err
+			}
+			image.SortOrder = i
+			ordered = append(ordered, image)
+		}
+
+		return ordered, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ordered, nil
+}
+
+// DeleteImage removes one of a product's images and its files
+func (s *ProductImageService) DeleteImage(productID, imageID uint) error {
+	image, err := s.imageRepo.GetByID(imageID)
+	if err != nil {
+		return err
+	}
+	if image.ProductID != productID {
+		return errors.New("image not found")
+	}
+
+	err = s.inProductTx(productID, func(tx *gorm.DB, product *models.Product, images []models.ProductImage) ([]models.ProductImage, error) {
+		if err := s.imageRepo.Delete(tx, imageID); err != nil {
+			return nil, err
+		}
+
+		remaining := make([]models.ProductImage, 0, len(images))
+		for _, other := range images {
+			if other.ID != imageID {
+				remaining = append(remaining, other)
+			}
+		}
+		return remaining, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.deleteFiles(image)
+	return nil
+}
+
+// inProductTx runs fn with the product locked and its current images, then mirrors the
+// first of the images fn returns into the product's ImageURL
+// When the last uploaded image is removed, an ImageURL that pointed at it is cleared, while an
+// external URL set by an admin is left alone.
+func (s *ProductImageService) inProductTx(productID uint, fn func(tx *gorm.DB, product *models.Product, images []models.ProductImage) ([]models.ProductImage, error)) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	product, err := s.productRepo.GetByIDWithLock(tx, productID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	images, err := s.imageRepo.ListByProduct(tx, productID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	images, err = fn(tx, product, images)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	imageURL := product.ImageURL
+	if len(images) > 0 {
+		imageURL = images[0].URL
+	} else if strings.HasPrefix(imageURL, s.publicURL("")) {
+		imageURL = ""
+	}
+
+	if imageURL != product.ImageURL {
+		err = tx.Model(&models.Product{}).
+			Where("id = ?", productID).
+			Update("image_url", imageURL).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// deleteFiles removes an image's files, logging failures since the record is already gone
+func (s *ProductImageService) deleteFiles(image *models.ProductImage) {
+	for _, key := range []string{image.StorageKey, image.ThumbnailKey} {
+		if err := s.storage.Delete(key); err != nil {
+			log.Printf("Failed to delete stored file %s: %v", key, err)
+		}
+	}
+}
+
+// OpenFile opens a stored file for serving
+func (s *ProductImageService) OpenFile(key string) (*storage.Object, error) {
+	return s.storage.Get(key)
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"time"
+)
+
+// priceDropWindow is how recently a price must have fallen for employees to see it as dropped
+const priceDropWindow = 30 * 24 * time.Hour
+
+// defaultPriceChangeReportDays is the period covered by the price change report when no range is given
+const defaultPriceChangeReportDays = 30
+
+// PriceChange is one recorded change to a product's price
+type PriceChange struct {
+	ID           uint      `json:"id"`
+	ProductID    uint      `json:"product_id"`
+	ProductName  string    `json:"product_name,omitempty"`
+	OldPoints    *int      `json:"old_points"` // Nil when the product was created
+	NewPoints    int       `json:"new_points"`
+	Difference   int       `json:"difference"` // NewPoints - OldPoints; 0 when the product was created
+	OperatorID   *uint     `json:"operator_id"`
+	OperatorName string    `json:"operator_name"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// newPriceChange builds a PriceChange from a history row with its operator and product preloaded
+func newPriceChange(history *models.ProductPriceHistory) PriceChange {
+	change := PriceChange{
+		ID:          history.ID,
+		ProductID:   history.ProductID,
+		ProductName: history.Product.Name,
+		OldPoints:   history.OldPoints,
+		NewPoints:   history.NewPoints,
+		OperatorID:  history.OperatorID,
+		CreatedAt:   history.CreatedAt,
+	}
+	if history.OldPoints != nil {
+		change.Difference = history.NewPoints - *history.OldPoints
+	}
+	if history.Operator != nil {
+		change.OperatorName = history.Operator.FullName
+	}
+	return change
+}
+
+// GetPriceHistory retrieves a product's price changes, newest first
+func (s *ProductService) GetPriceHistory(productID uint) ([]PriceChange, error) {
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.priceHistoryRepo.ListByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]PriceChange, 0, len(history))
+	for i := range history {
+		change := newPriceChange(&history[i])
+		change.ProductName = product.Name
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// PriceChangeReport lists price changes made across the catalog in [From, To)
+// Product creations are not counted as increases or decreases.
+type PriceChangeReport struct {
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Changes     []PriceChange `json:"changes"`
+	Increases   int           `json:"increases"`
+	Decreases   int           `json:"decreases"`
+	GeneratedAt time.Time     `json:"generated_at"`
+}
+
+// GetPriceChangeReport lists price changes made in a date range, defaulting to the last 30 days
+func (s *ProductService) GetPriceChangeReport(from, to *time.Time) (*PriceChangeReport, error) {
+	now := time.Now()
+	report := &PriceChangeReport{
+		From:        now.AddDate(0, 0, -defaultPriceChangeReportDays),
+		To:          now,
+		Changes:     []PriceChange{},
+		GeneratedAt: now,
+	}
+	if from != nil {
+		report.From = *from
+	}
+	if to != nil {
+		report.To = *to
+	}
+
+	if !report.From.Before(report.To) {
+		return nil, errors.New("from must be before to")
+	}
+
+	history, err := s.priceHistoryRepo.ListInRange(report.From, report.To)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range history {
+		change := newPriceChange(&history[i])
+		switch {
+		case change.Difference > 0:
+			report.Increases++
+		case change.Difference < 0:
+			report.Decreases++
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	return report, nil
+}
+
+// markPriceDrops flags products whose price is lower than it was at the start of the price drop window
+// For products created within the window, the launch price is the reference.
+func (s *ProductService) markPriceDrops(products ...*models.Product) error {
+	ids := make([]uint, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	earliest, err := s.priceHistoryRepo.GetEarliestSince(ids, time.Now().Add(-priceDropWindow))
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		change, ok := earliest[product.ID]
+		if !ok {
+			continue
+		}
+
+		reference := change.NewPoints
+		if change.OldPoints != nil {
+			reference = *change.OldPoints
+		}
+
+		if product.PointsRequired < reference {
+			product.PriceDropped = true
+			product.PreviousPoints = &reference
+		}
+	}
+
+	return nil
+}
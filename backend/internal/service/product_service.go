@@ -1,42 +1,125 @@
 package service
 
 import (
+	"awsome-shop/internal/events"
 	"awsome-shop/internal/models"
 	"awsome-shop/internal/repository"
 	"errors"
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProductService handles product management operations
 type ProductService struct {
 	productRepo        *repository.ProductRepository
-	priceHistoryRepo   *repository.ProductRepository // For price history operations
+	priceHistoryRepo   *repository.ProductPriceHistoryRepository
+	tagRepo            *repository.TagRepository
+	variantRepo        *repository.ProductVariantRepository
+	movementRepo       *repository.InventoryMovementRepository
+	userRepo           *repository.UserRepository
+	searcher           repository.ProductSearcher
+	categoryService    *CategoryService
+	events             *events.Bus
 	db                 *gorm.DB
 }
 
 // NewProductService creates a new ProductService instance
 func NewProductService(
 	productRepo *repository.ProductRepository,
+	priceHistoryRepo *repository.ProductPriceHistoryRepository,
+	tagRepo *repository.TagRepository,
+	variantRepo *repository.ProductVariantRepository,
+	movementRepo *repository.InventoryMovementRepository,
+	userRepo *repository.UserRepository,
+	searcher repository.ProductSearcher,
+	categoryService *CategoryService,
+	eventBus *events.Bus,
 	db *gorm.DB,
 ) *ProductService {
 	return &ProductService{
 		productRepo:      productRepo,
-		priceHistoryRepo: productRepo,
+		priceHistoryRepo: priceHistoryRepo,
+		tagRepo:          tagRepo,
+		variantRepo:      variantRepo,
+		movementRepo:     movementRepo,
+		userRepo:         userRepo,
+		searcher:         searcher,
+		categoryService:  categoryService,
+		events:           eventBus,
 		db:               db,
 	}
 }
 
+// Limits on localized product content, in characters
+const (
+	maxProductNameLength             = 200
+	maxProductShortDescriptionLength = 500
+	maxProductDescriptionLength      = 20000
+)
+
+// normalizeProductContent trims and sanitizes a product's localized content and checks its limits
+// At least one locale must have a name. The stored Name is kept as the Chinese name, or the
+// English one when there is none, for consumers that do not localize.
+func normalizeProductContent(product *models.Product) error {
+	product.NameZh = strings.TrimSpace(product.NameZh)
+	product.NameEn = strings.TrimSpace(product.NameEn)
+	product.ShortDescriptionZh = strings.TrimSpace(product.ShortDescriptionZh)
+	product.ShortDescriptionEn = strings.TrimSpace(product.ShortDescriptionEn)
+	product.DescriptionZh = sanitizeMarkdown(product.DescriptionZh)
+	product.DescriptionEn = sanitizeMarkdown(product.DescriptionEn)
+
+	if product.NameZh == "" && product.NameEn == "" {
+		return errors.New("name_zh or name_en is required")
+	}
+
+	limits := []struct {
+		field string
+		value string
+		max   int
+	}{
+		{"name_zh", product.NameZh, maxProductNameLength},
+		{"name_en", product.NameEn, maxProductNameLength},
+		{"short_description_zh", product.ShortDescriptionZh, maxProductShortDescriptionLength},
+		{"short_description_en", product.ShortDescriptionEn, maxProductShortDescriptionLength},
+		{"description_zh", product.DescriptionZh, maxProductDescriptionLength},
+		{"description_en", product.DescriptionEn, maxProductDescriptionLength},
+	}
+	for _, limit := range limits {
+		if utf8.RuneCountInString(limit.value) > limit.max {
+			return fmt.Errorf("%s cannot be longer than %d characters", limit.field, limit.max)
+		}
+	}
+
+	product.Name = product.LocalizedName(models.LanguageZh)
+	return nil
+}
+
 // CreateProductRequest represents a request to create a product
 type CreateProductRequest struct {
-	Name           string `json:"name" binding:"required"`
-	ImageURL       string `json:"image_url"`
-	PointsRequired int    `json:"points_required" binding:"required,min=1"`
-	StockQuantity  int    `json:"stock_quantity" binding:"min=0"`
+	Name               string     `json:"name"` // Deprecated single name, used as name_zh when neither locale is given
+	NameZh             string     `json:"name_zh"`
+	NameEn             string     `json:"name_en"`
+	ShortDescriptionZh string     `json:"short_description_zh"`
+	ShortDescriptionEn string     `json:"short_description_en"`
+	DescriptionZh      string     `json:"description_zh"` // Markdown; raw HTML is stripped
+	DescriptionEn      string     `json:"description_en"` // Markdown; raw HTML is stripped
+	ImageURL           string     `json:"image_url"`
+	PointsRequired     int        `json:"points_required" binding:"required,min=1"`
+	StockQuantity      int        `json:"stock_quantity" binding:"min=0"`
+	LowStockThreshold  int        `json:"low_stock_threshold" binding:"min=0"`
+	AcceptedWallets    []string   `json:"accepted_wallets"` // Empty accepts every wallet
+	CategoryID         *uint      `json:"category_id"`
+	Tags               []string   `json:"tags"`
+	AvailableFrom      *time.Time `json:"available_from"`
+	AvailableUntil     *time.Time `json:"available_until"`
 }
 
 // CreateProduct creates a new product and records initial price history
@@ -46,6 +129,52 @@ func (s *ProductService) CreateProduct(req *CreateProductRequest, operatorID uin
 		return nil, errors.New("points required must be greater than 0")
 	}
 
+	acceptedWallets, err := normalizeAcceptedWallets(req.AcceptedWallets)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryID, err := s.resolveCategoryID(req.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := normalizeTagNames(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateAvailabilityWindow(req.AvailableFrom, req.AvailableUntil); err != nil {
+		return nil, err
+	}
+
+	product := &models.Product{
+		NameZh:             req.NameZh,
+		NameEn:             req.NameEn,
+		ShortDescriptionZh: req.ShortDescriptionZh,
+		ShortDescriptionEn: req.ShortDescriptionEn,
+		DescriptionZh:      req.DescriptionZh,
+		DescriptionEn:      req.DescriptionEn,
+		ImageURL:           req.ImageURL,
+		PointsRequired:     req.PointsRequired,
+		StockQuantity:      req.StockQuantity,
+		LowStockThreshold:  req.LowStockThreshold,
+		AcceptedWallets:    acceptedWallets,
+		CategoryID:         categoryID,
+		AvailableFrom:      req.AvailableFrom,
+		AvailableUntil:     req.AvailableUntil,
+		Status:             "active",
+	}
+	if product.NameZh == "" && product.NameEn == "" {
+		product.NameZh = req.Name
+	}
+	applyAvailabilityWindow(product, time.Now())
+
+	err = normalizeProductContent(product)
+	if err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -59,15 +188,19 @@ func (s *ProductService) CreateProduct(req *CreateProductRequest, operatorID uin
 	}()
 
 	// Create product
-	product := &models.Product{
-		Name:           req.Name,
-		ImageURL:       req.ImageURL,
-		PointsRequired: req.PointsRequired,
-		StockQuantity:  req.StockQuantity,
-		Status:         "active",
+	err = tx.Create(product).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = s.setProductTags(tx, product, tags)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
-	err := tx.Create(product).Error
+	err = s.recordInitialStock(tx, product, operatorID)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
@@ -93,25 +226,29 @@ func (s *ProductService) CreateProduct(req *CreateProductRequest, operatorID uin
 		return nil, err
 	}
 
-	return product, nil
+	return s.productRepo.GetByID(product.ID)
 }
 
 // UpdateProductRequest represents a request to update a product
 type UpdateProductRequest struct {
-	Name           *string `json:"name"`
-	ImageURL       *string `json:"image_url"`
-	PointsRequired *int    `json:"points_required"`
-	StockQuantity  *int    `json:"stock_quantity"`
+	Name               *string  `json:"name"` // Deprecated single name, updates name_zh
+	NameZh             *string  `json:"name_zh"`
+	NameEn             *string  `json:"name_en"`
+	ShortDescriptionZh *string  `json:"short_description_zh"`
+	ShortDescriptionEn *string  `json:"short_description_en"`
+	DescriptionZh      *string  `json:"description_zh"`
+	DescriptionEn      *string  `json:"description_en"`
+	ImageURL           *string  `json:"image_url"`
+	PointsRequired     *int     `json:"points_required"`
+	StockQuantity      *int     `json:"stock_quantity"`
+	LowStockThreshold  *int     `json:"low_stock_threshold"`
+	AcceptedWallets    []string `json:"accepted_wallets"` // Nil leaves the accepted wallets unchanged
+	CategoryID         *uint    `json:"category_id"`      // Nil leaves the category unchanged, 0 clears it
+	Tags               []string `json:"tags"`             // Nil leaves the tags unchanged, empty clears them
 }
 
 // UpdateProduct updates a product and records price change if applicable
 func (s *ProductService) UpdateProduct(productID uint, req *UpdateProductRequest, operatorID uint) (*models.Product, error) {
-	// Get existing product
-	product, err := s.productRepo.GetByID(productID)
-	if err != nil {
-		return nil, err
-	}
-
 	// Start transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -124,13 +261,48 @@ func (s *ProductService) UpdateProduct(productID uint, req *UpdateProductRequest
 		}
 	}()
 
-	// Track if points changed
+	// Lock the product while reading it, so saving the whole row below cannot overwrite
+	// a stock change committed by a concurrent redemption
+	product, err := s.productRepo.GetDetailByIDWithLock(tx, productID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Track if points or stock changed
 	pointsChanged := false
 	oldPoints := product.PointsRequired
+	oldStock := product.StockQuantity
 
 	// Update fields
 	if req.Name != nil {
-		product.Name = *req.Name
+		product.NameZh = *req.Name
+	}
+	if req.NameZh != nil {
+		product.NameZh = *req.NameZh
+	}
+	if req.NameEn != nil {
+		product.NameEn = *req.NameEn
+	}
+	if req.ShortDescriptionZh != nil {
+		product.ShortDescriptionZh = *req.ShortDescriptionZh
+	}
+	if req.ShortDescriptionEn != nil {
+		product.ShortDescriptionEn = *req.ShortDescriptionEn
+	}
+	if req.DescriptionZh != nil {
+		product.DescriptionZh = *req.DescriptionZh
+	}
+	if req.DescriptionEn != nil {
+		product.DescriptionEn = *req.DescriptionEn
+	}
+	if product.NameZh == "" && product.NameEn == "" {
+		// Products from before per-locale names keep their original name
+		product.NameZh = product.Name
+	}
+	if err := normalizeProductContent(product); err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 	if req.ImageURL != nil {
 		product.ImageURL = *req.ImageURL
@@ -146,20 +318,76 @@ func (s *ProductService) UpdateProduct(productID uint, req *UpdateProductRequest
 		}
 	}
 	if req.StockQuantity != nil {
+		if hasActiveVariants(product) {
+			tx.Rollback()
+			return nil, errors.New("stock is managed per variant for this product")
+		}
 		if *req.StockQuantity < 0 {
 			tx.Rollback()
 			return nil, errors.New("stock quantity cannot be negative")
 		}
 		product.StockQuantity = *req.StockQuantity
 	}
+	if req.LowStockThreshold != nil {
+		if *req.LowStockThreshold < 0 {
+			tx.Rollback()
+			return nil, errors.New("low stock threshold cannot be negative")
+		}
+		product.LowStockThreshold = *req.LowStockThreshold
+	}
+	if req.AcceptedWallets != nil {
+		acceptedWallets, err := normalizeAcceptedWallets(req.AcceptedWallets)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		product.AcceptedWallets = acceptedWallets
+	}
+	if req.CategoryID != nil {
+		categoryID, err := s.resolveCategoryID(req.CategoryID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		product.CategoryID = categoryID
+	}
 
-	// Save product
-	err = tx.Save(product).Error
+	// Save product; tags are replaced separately below
+	err = tx.Omit(clause.Associations).Save(product).Error
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
+	// A stock edit without a stated reason is recorded as an adjustment
+	if product.StockQuantity != oldStock {
+		err = s.movementRepo.Record(tx, &models.InventoryMovement{
+			ProductID:    product.ID,
+			MovementType: models.MovementAdjust,
+			Quantity:     product.StockQuantity - oldStock,
+			OperatorID:   &operatorID,
+			Note:         "Stock edited on the product",
+		})
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	if req.Tags != nil {
+		tags, err := normalizeTagNames(req.Tags)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		err = s.setProductTags(tx, product, tags)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	// If points changed, create price history record
 	if pointsChanged {
 		priceHistory := &models.ProductPriceHistory{
@@ -182,7 +410,9 @@ func (s *ProductService) UpdateProduct(productID uint, req *UpdateProductRequest
 		return nil, err
 	}
 
-	return product, nil
+	publishLowStock(s.events, product, oldStock, product.StockQuantity)
+
+	return s.productRepo.GetByID(product.ID)
 }
 
 // SetProductStatus sets a product's status (active/inactive)
@@ -194,9 +424,176 @@ func (s *ProductService) SetProductStatus(productID uint, status string) error {
 	return s.productRepo.UpdateStatus(productID, status)
 }
 
-// GetActiveProducts retrieves all active products
-func (s *ProductService) GetActiveProducts() ([]models.Product, error) {
-	return s.productRepo.GetActiveProducts()
+// resolveCategoryID checks that a requested category exists, treating 0 as no category
+func (s *ProductService) resolveCategoryID(categoryID *uint) (*uint, error) {
+	if categoryID == nil || *categoryID == 0 {
+		return nil, nil
+	}
+
+	if _, err := s.categoryService.GetCategoryByID(*categoryID); err != nil {
+		return nil, err
+	}
+
+	id := *categoryID
+	return &id, nil
+}
+
+// setProductTags replaces a product's tags inside tx, creating tags that do not exist yet
+func (s *ProductService) setProductTags(tx *gorm.DB, product *models.Product, names []string) error {
+	tags, err := s.tagRepo.FindOrCreateByNames(tx, names)
+	if err != nil {
+		return err
+	}
+
+	product.Tags = tags
+	return tx.Model(product).Association("Tags").Replace(tags)
+}
+
+// recordInitialStock records the stock a new product was created with as received
+func (s *ProductService) recordInitialStock(tx *gorm.DB, product *models.Product, operatorID uint) error {
+	if product.StockQuantity == 0 {
+		return nil
+	}
+
+	return s.movementRepo.Record(tx, &models.InventoryMovement{
+		ProductID:    product.ID,
+		MovementType: models.MovementReceive,
+		Quantity:     product.StockQuantity,
+		OperatorID:   &operatorID,
+		Note:         "Initial stock",
+	})
+}
+
+// preferredLanguage returns the content language a user has chosen
+func (s *ProductService) preferredLanguage(userID uint) (string, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", err
+	}
+	return user.PreferredLanguage, nil
+}
+
+// ProductCatalogQuery describes a catalog search by an employee
+type ProductCatalogQuery struct {
+	Keyword     string
+	CategoryID  *uint    // Includes products in all of the category's subcategories
+	Tags        []string // Only matches products carrying every listed tag
+	MinPoints   *int
+	MaxPoints   *int
+	InStockOnly bool
+	Sort        string // Defaults to relevance with a keyword and newest without
+	Page        int
+	PageSize    int
+}
+
+// ProductCatalogPage is one page of catalog search results
+type ProductCatalogPage struct {
+	Products []models.Product `json:"products"`
+	Total    int64            `json:"total"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+}
+
+// Catalog search limits
+const (
+	defaultCatalogPageSize  = 20
+	maxCatalogPageSize      = 100
+	maxCatalogKeywordLength = 100
+)
+
+// GetActiveProducts searches active products and returns one page in the user's language
+func (s *ProductService) GetActiveProducts(userID uint, query ProductCatalogQuery) (*ProductCatalogPage, error) {
+	language, err := s.preferredLanguage(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	search := repository.ProductSearchQuery{
+		Keyword:     strings.TrimSpace(query.Keyword),
+		MinPoints:   query.MinPoints,
+		MaxPoints:   query.MaxPoints,
+		InStockOnly: query.InStockOnly,
+		AvailableAt: time.Now(),
+		Sort:        query.Sort,
+		Page:        query.Page,
+		PageSize:    query.PageSize,
+	}
+
+	if utf8.RuneCountInString(search.Keyword) > maxCatalogKeywordLength {
+		return nil, fmt.Errorf("keyword cannot be longer than %d characters", maxCatalogKeywordLength)
+	}
+
+	if search.MinPoints != nil && search.MaxPoints != nil && *search.MinPoints > *search.MaxPoints {
+		return nil, errors.New("min_points cannot be greater than max_points")
+	}
+
+	if search.Sort == "" {
+		search.Sort = repository.ProductSortNewest
+		if search.Keyword != "" {
+			search.Sort = repository.ProductSortRelevance
+		}
+	}
+	validSort := false
+	for _, sortOrder := range repository.ProductSortOrders {
+		if search.Sort == sortOrder {
+			validSort = true
+			break
+		}
+	}
+	if !validSort {
+		return nil, fmt.Errorf("invalid sort: must be one of %s", strings.Join(repository.ProductSortOrders, ", "))
+	}
+
+	if search.Page <= 0 {
+		search.Page = 1
+	}
+	if search.PageSize <= 0 {
+		search.PageSize = defaultCatalogPageSize
+	}
+	if search.PageSize > maxCatalogPageSize {
+		search.PageSize = maxCatalogPageSize
+	}
+
+	if query.CategoryID != nil {
+		ids, err := s.categoryService.GetCategoryWithDescendants(*query.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		search.CategoryIDs = ids
+	}
+
+	search.Tags, err = normalizeTagNames(query.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.searcher.Search(search)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.productRepo.GetByIDs(result.ProductIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	page := make([]*models.Product, len(products))
+	for i := range products {
+		products[i].Localize(language)
+		activeVariants(&products[i])
+		page[i] = &products[i]
+	}
+
+	if err := s.markPriceDrops(page...); err != nil {
+		return nil, err
+	}
+
+	return &ProductCatalogPage{
+		Products: products,
+		Total:    result.Total,
+		Page:     search.Page,
+		PageSize: search.PageSize,
+	}, nil
 }
 
 // GetProductByID retrieves a product by ID
@@ -204,34 +601,83 @@ func (s *ProductService) GetProductByID(productID uint) (*models.Product, error)
 	return s.productRepo.GetByID(productID)
 }
 
+// GetProductForUser retrieves a product by ID in the user's language
+func (s *ProductService) GetProductForUser(productID, userID uint) (*models.Product, error) {
+	language, err := s.preferredLanguage(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	product.Localize(language)
+	activeVariants(product)
+
+	if err := s.markPriceDrops(product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
 // ListProducts lists all products with optional status filter
 func (s *ProductService) ListProducts(status *string) ([]models.Product, error) {
 	return s.productRepo.List(status)
 }
 
+// ListLowStockProducts lists active products whose stock is at or below their low-stock threshold
+func (s *ProductService) ListLowStockProducts() ([]models.Product, error) {
+	return s.productRepo.ListLowStock()
+}
+
 // BatchImportProduct represents a product for batch import
 type BatchImportProduct struct {
-	Name          string
-	ImageURL      string
-	StockQuantity int
+	Row            int
+	Name           string
+	NameZh         string
+	NameEn         string
+	ImageURL       string
+	StockQuantity  int
 	PointsRequired int
+	CategoryPath   string   // Slash-separated category path, empty for none
+	Tags           []string // Tags to attach, created if they do not exist
 }
 
 // ParseMarkdownTable parses a markdown table for batch product import
 // Expected format:
-// | 商品名称（中英文） | 商品主图 | 商品数量 | 所需积分 |
-// |-------------------|---------|---------|---------|
-// | Product Name      | url     | 10      | 100     |
+// | 商品名称（中英文） | 商品主图 | 商品数量 | 所需积分 | 分类      | 标签        |
+// |-------------------|---------|---------|---------|-----------|-------------|
+// | 蓝牙耳机 / Earbuds | url    | 10      | 100     | 数码/耳机 | 新品,热门   |
+// A name written as "中文 / English" is split into its Chinese and English names; any other
+// name is taken as the Chinese name. The 分类 and 标签 columns are optional. 分类 is a path to an
+// existing category and 标签 is a comma-separated list of tags.
 func (s *ProductService) ParseMarkdownTable(markdown string) ([]BatchImportProduct, error) {
+	products, rowErrors, err := parseProductRows(markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rowErrors) > 0 {
+		return nil, fmt.Errorf("invalid row %d: %s", rowErrors[0].Row, rowErrors[0].Message)
+	}
+
+	return products, nil
+}
+
+// parseProductRows parses a product import table, collecting malformed rows instead of stopping at the first one
+func parseProductRows(markdown string) ([]BatchImportProduct, []BatchRowError, error) {
 	lines := strings.Split(strings.TrimSpace(markdown), "\n")
-	
+
 	if len(lines) < 3 {
-		return nil, errors.New("invalid markdown table: must have header, separator, and at least one data row")
+		return nil, nil, errors.New("invalid markdown table: must have header, separator, and at least one data row")
 	}
 
 	// Skip header (line 0) and separator (line 1)
 	var products []BatchImportProduct
-	
+	var rowErrors []BatchRowError
+
 	for i := 2; i < len(lines); i++ {
 		line := strings.TrimSpace(lines[i])
 		if line == "" {
@@ -241,11 +687,12 @@ func (s *ProductService) ParseMarkdownTable(markdown string) ([]BatchImportProdu
 		// Parse table row
 		// Remove leading and trailing pipes
 		line = strings.Trim(line, "|")
-		
+
 		// Split by pipe
 		fields := strings.Split(line, "|")
 		if len(fields) < 4 {
-			return nil, fmt.Errorf("invalid row %d: expected 4 columns", i-1)
+			rowErrors = append(rowErrors, BatchRowError{Row: i - 1, Message: "expected 4 columns"})
+			continue
 		}
 
 		// Trim whitespace from each field
@@ -256,54 +703,190 @@ func (s *ProductService) ParseMarkdownTable(markdown string) ([]BatchImportProdu
 		// Parse stock quantity
 		stockQuantity, err := strconv.Atoi(fields[2])
 		if err != nil {
-			return nil, fmt.Errorf("invalid stock quantity in row %d: %s", i-1, fields[2])
+			rowErrors = append(rowErrors, BatchRowError{Row: i - 1, Message: fmt.Sprintf("invalid stock quantity: %s", fields[2])})
+			continue
 		}
 
-		// Parse points required (optional, default to 0 if not provided or in wrong format)
+		// Parse points required (optional, default to 0 if not provided)
 		pointsRequired := 0
-		if len(fields) > 3 && fields[3] != "" {
+		if fields[3] != "" {
 			pointsRequired, err = strconv.Atoi(fields[3])
 			if err != nil {
-				return nil, fmt.Errorf("invalid points required in row %d: %s", i-1, fields[3])
+				rowErrors = append(rowErrors, BatchRowError{Row: i - 1, Message: fmt.Sprintf("invalid points required: %s", fields[3])})
+				continue
 			}
 		}
 
 		product := BatchImportProduct{
-			Name:          fields[0],
-			ImageURL:      fields[1],
-			StockQuantity: stockQuantity,
+			Row:            i - 1,
+			Name:           fields[0],
+			NameZh:         fields[0],
+			ImageURL:       fields[1],
+			StockQuantity:  stockQuantity,
 			PointsRequired: pointsRequired,
 		}
 
+		if zh, en, found := strings.Cut(fields[0], " / "); found {
+			product.NameZh = strings.TrimSpace(zh)
+			product.NameEn = strings.TrimSpace(en)
+		}
+
+		// Parse optional category and tag columns
+		if len(fields) > 4 {
+			product.CategoryPath = fields[4]
+		}
+		if len(fields) > 5 {
+			tags, err := normalizeTagNames(strings.Split(strings.ReplaceAll(fields[5], "，", ","), ","))
+			if err != nil {
+				rowErrors = append(rowErrors, BatchRowError{Row: i - 1, Message: err.Error()})
+				continue
+			}
+			product.Tags = tags
+		}
+
 		products = append(products, product)
 	}
 
-	if len(products) == 0 {
-		return nil, errors.New("no valid products found in markdown table")
+	if len(products) == 0 && len(rowErrors) == 0 {
+		return nil, nil, errors.New("no valid products found in markdown table")
 	}
 
-	return products, nil
+	return products, rowErrors, nil
+}
+
+// validateImportProduct checks a single import row and returns a failure message, if any
+func validateImportProduct(p BatchImportProduct) string {
+	if p.Name == "" {
+		return "name is required"
+	}
+	content := models.Product{NameZh: p.NameZh, NameEn: p.NameEn}
+	if err := normalizeProductContent(&content); err != nil {
+		return err.Error()
+	}
+	if p.StockQuantity < 0 {
+		return "stock quantity cannot be negative"
+	}
+	if p.PointsRequired <= 0 {
+		return "points required must be greater than 0"
+	}
+	return ""
+}
+
+// BatchImportRowResult reports the outcome of a single row in a batch product import
+type BatchImportRowResult struct {
+	Row            int      `json:"row"`
+	Name           string   `json:"name"`
+	ImageURL       string   `json:"image_url"`
+	StockQuantity  int      `json:"stock_quantity"`
+	PointsRequired int      `json:"points_required"`
+	Category       string   `json:"category,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Status         string   `json:"status"` // valid, applied, failed
+	Error          string   `json:"error,omitempty"`
+	ProductID      uint     `json:"product_id,omitempty"`
+}
+
+// BatchImportResult summarizes a batch product import, including dry runs
+type BatchImportResult struct {
+	DryRun    bool                   `json:"dry_run"`
+	Partial   bool                   `json:"partial"`
+	Total     int                    `json:"total"`
+	Succeeded int                    `json:"succeeded"`
+	Failed    int                    `json:"failed"`
+	Rows      []BatchImportRowResult `json:"rows"`
+	Products  []models.Product       `json:"-"`
 }
 
 // BatchImportProducts imports multiple products from markdown table
-func (s *ProductService) BatchImportProducts(markdown string, operatorID uint) ([]models.Product, error) {
+// With DryRun set, every row is validated and the products that would be created are returned
+// without writing anything. With Partial set, valid rows are created and failed rows are
+// reported; otherwise any failed row rejects the whole batch.
+func (s *ProductService) BatchImportProducts(markdown string, operatorID uint, opts BatchOptions) (*BatchImportResult, error) {
 	// Parse markdown table
-	importProducts, err := s.ParseMarkdownTable(markdown)
+	importProducts, parseErrors, err := parseProductRows(markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchImportResult{DryRun: opts.DryRun, Partial: opts.Partial}
+	for _, rowErr := range parseErrors {
+		result.Rows = append(result.Rows, BatchImportRowResult{
+			Row:    rowErr.Row,
+			Status: "failed",
+			Error:  rowErr.Message,
+		})
+	}
+
+	categories, err := s.categoryService.ListCategories()
 	if err != nil {
 		return nil, err
 	}
 
 	// Validate all products first
-	for i, p := range importProducts {
-		if p.Name == "" {
-			return nil, fmt.Errorf("product %d: name is required", i+1)
+	var validProducts []BatchImportProduct
+	categoryIDs := make(map[int]*uint)
+	for _, p := range importProducts {
+		rowResult := BatchImportRowResult{
+			Row:            p.Row,
+			Name:           p.Name,
+			ImageURL:       p.ImageURL,
+			StockQuantity:  p.StockQuantity,
+			PointsRequired: p.PointsRequired,
+			Category:       p.CategoryPath,
+			Tags:           p.Tags,
+			Status:         "valid",
 		}
-		if p.StockQuantity < 0 {
-			return nil, fmt.Errorf("product %d: stock quantity cannot be negative", i+1)
+
+		msg := validateImportProduct(p)
+		if msg == "" && p.CategoryPath != "" {
+			category, err := findCategoryByPath(categories, p.CategoryPath)
+			if err != nil {
+				msg = err.Error()
+			} else {
+				categoryIDs[p.Row] = &category.ID
+			}
 		}
-		if p.PointsRequired <= 0 {
-			return nil, fmt.Errorf("product %d: points required must be greater than 0", i+1)
+
+		if msg != "" {
+			rowResult.Status = "failed"
+			rowResult.Error = msg
+		} else {
+			validProducts = append(validProducts, p)
 		}
+
+		result.Rows = append(result.Rows, rowResult)
+	}
+
+	sort.Slice(result.Rows, func(i, j int) bool {
+		return result.Rows[i].Row < result.Rows[j].Row
+	})
+
+	var rowErrors []BatchRowError
+	for _, row := range result.Rows {
+		result.Total++
+		if row.Status == "failed" {
+			result.Failed++
+			rowErrors = append(rowErrors, BatchRowError{Row: row.Row, Message: row.Error})
+			continue
+		}
+		result.Succeeded++
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	if len(rowErrors) > 0 && !opts.Partial {
+		return nil, &BatchValidationError{Rows: rowErrors}
+	}
+
+	if len(validProducts) == 0 {
+		return result, nil
+	}
+
+	rowIndex := make(map[int]int, len(result.Rows))
+	for i, row := range result.Rows {
+		rowIndex[row.Row] = i
 	}
 
 	// Start transaction
@@ -318,16 +901,23 @@ func (s *ProductService) BatchImportProducts(markdown string, operatorID uint) (
 		}
 	}()
 
-	var createdProducts []models.Product
-
-	// Create all products
-	for _, p := range importProducts {
+	// Create all valid products
+	for _, p := range validProducts {
 		product := models.Product{
-			Name:           p.Name,
-			ImageURL:       p.ImageURL,
-			PointsRequired: p.PointsRequired,
-			StockQuantity:  p.StockQuantity,
-			Status:         "active",
+			NameZh:          p.NameZh,
+			NameEn:          p.NameEn,
+			ImageURL:        p.ImageURL,
+			PointsRequired:  p.PointsRequired,
+			StockQuantity:   p.StockQuantity,
+			AcceptedWallets: strings.Join(models.WalletTypes, ","),
+			CategoryID:      categoryIDs[p.Row],
+			Status:          "active",
+		}
+
+		err = normalizeProductContent(&product)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
 		}
 
 		err = tx.Create(&product).Error
@@ -336,6 +926,18 @@ func (s *ProductService) BatchImportProducts(markdown string, operatorID uint) (
 			return nil, err
 		}
 
+		err = s.setProductTags(tx, &product, p.Tags)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		err = s.recordInitialStock(tx, &product, operatorID)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
 		// Create price history
 		priceHistory := &models.ProductPriceHistory{
 			ProductID:  product.ID,
@@ -350,7 +952,11 @@ func (s *ProductService) BatchImportProducts(markdown string, operatorID uint) (
 			return nil, err
 		}
 
-		createdProducts = append(createdProducts, product)
+		row := &result.Rows[rowIndex[p.Row]]
+		row.Status = "applied"
+		row.ProductID = product.ID
+
+		result.Products = append(result.Products, product)
 	}
 
 	// Commit transaction
@@ -359,7 +965,7 @@ func (s *ProductService) BatchImportProducts(markdown string, operatorID uint) (
 		return nil, err
 	}
 
-	return createdProducts, nil
+	return result, nil
 }
 
 // ValidateProductAvailable checks if a product is available for redemption
@@ -373,6 +979,10 @@ func (s *ProductService) ValidateProductAvailable(productID uint) error {
 		return errors.New("product is not active")
 	}
 
+	if !product.IsAvailableAt(time.Now()) {
+		return errors.New("product is not available at this time")
+	}
+
 	if product.StockQuantity <= 0 {
 		return errors.New("product is out of stock")
 	}
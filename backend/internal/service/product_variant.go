@@ -0,0 +1,324 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// ProductVariantRequest represents a request to create or update a product variant
+type ProductVariantRequest struct {
+	Attributes     []models.VariantAttribute `json:"attributes"`
+	PointsOverride *int                      `json:"points_override"` // Nil uses the product's price
+	StockQuantity  int                       `json:"stock_quantity"`
+	SortOrder      int                       `json:"sort_order"`
+}
+
+// normalizeVariantAttributes trims attributes and checks that each one is named once and has a value
+func normalizeVariantAttributes(attributes []models.VariantAttribute) ([]models.VariantAttribute, error) {
+	if len(attributes) == 0 {
+		return nil, errors.New("at least one attribute is required")
+	}
+
+	seen := make(map[string]bool, len(attributes))
+	normalized := make([]models.VariantAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		name := strings.TrimSpace(attribute.Name)
+		value := strings.TrimSpace(attribute.Value)
+		if name == "" || value == "" {
+			return nil, errors.New("attribute name and value are required")
+		}
+		if seen[strings.ToLower(name)] {
+			return nil, fmt.Errorf("duplicate attribute: %s", name)
+		}
+		seen[strings.ToLower(name)] = true
+		normalized = append(normalized, models.VariantAttribute{Name: name, Value: value})
+	}
+
+	if utf8.RuneCountInString(models.VariantLabel(normalized)) > maxProductNameLength {
+		return nil, fmt.Errorf("variant label cannot be longer than %d characters", maxProductNameLength)
+	}
+
+	return normalized, nil
+}
+
+// applyVariantRequest validates a variant request and copies it onto variant
+func (s *ProductService) applyVariantRequest(variant *models.ProductVariant, req *ProductVariantRequest) error {
+	attributes, err := normalizeVariantAttributes(req.Attributes)
+	if err != nil {
+		return err
+	}
+
+	if req.PointsOverride != nil && *req.PointsOverride <= 0 {
+		return errors.New("points override must be greater than 0")
+	}
+	if req.StockQuantity < 0 {
+		return errors.New("stock quantity cannot be negative")
+	}
+
+	label := models.VariantLabel(attributes)
+	exists, err := s.variantRepo.ExistsByLabel(variant.ProductID, label, variant.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("the product already has a variant with these attributes")
+	}
+
+	variant.Attributes = attributes
+	variant.Label = label
+	variant.PointsOverride = req.PointsOverride
+	variant.StockQuantity = req.StockQuantity
+	variant.SortOrder = req.SortOrder
+	return nil
+}
+
+// saveVariant writes a variant, refreshes its product's total stock and records any stock
+// change as an inventory movement, in one transaction
+// The product row is locked first, in the same order as redemptions, to avoid deadlocks.
+func (s *ProductService) saveVariant(variant *models.ProductVariant, isNew bool, operatorID uint) error {
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	product, err := s.productRepo.GetByIDWithLock(tx, variant.ProductID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// The stored row, for comparing stock and status once the variant is saved
+	var previous *models.ProductVariant
+	if !isNew {
+		previous, err = s.variantRepo.GetByIDWithLock(tx, variant.ID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	// Once a product has an active variant its stock is the sum of its variants' stock. Clear
+	// any standalone stock first and record it, so the units do not vanish from the ledger.
+	if variant.Status == "active" && product.StockQuantity > 0 {
+		activeCount, err := s.variantRepo.CountActiveByProduct(tx, variant.ProductID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+
+		if activeCount == 0 {
+			err = s.clearStandaloneStock(tx, product, operatorID)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+	}
+
+	if isNew {
+		err = s.variantRepo.Create(tx, variant)
+	} else {
+		err = s.variantRepo.Update(tx, variant)
+	}
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = s.variantRepo.SyncProductStock(tx, variant.ProductID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	movement := variantMovement(previous, variant)
+	if movement != nil {
+		movement.OperatorID = &operatorID
+		err = s.movementRepo.Record(tx, movement)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	if movement != nil {
+		publishLowStock(s.events, product, product.StockQuantity, movement.ProductStockAfter)
+	}
+
+	return nil
+}
+
+// clearStandaloneStock sets a product's own stock to 0 inside tx and records it as an adjustment
+func (s *ProductService) clearStandaloneStock(tx *gorm.DB, product *models.Product, operatorID uint) error {
+	err := s.productRepo.AdjustStock(tx, product.ID, -product.StockQuantity)
+	if err != nil {
+		return err
+	}
+
+	return s.movementRepo.Record(tx, &models.InventoryMovement{
+		ProductID:    product.ID,
+		MovementType: models.MovementAdjust,
+		Quantity:     -product.StockQuantity,
+		OperatorID:   &operatorID,
+		Note:         "Standalone stock cleared; stock is now managed per variant",
+	})
+}
+
+// variantMovement describes how saving a variant changed stock, or returns nil if it did not
+// Quantity is the change to the product's total stock, which only counts active variants:
+// activating a variant adds its stock, deactivating one removes the stock it had.
+func variantMovement(previous, variant *models.ProductVariant) *models.InventoryMovement {
+	movement := &models.InventoryMovement{
+		ProductID:    variant.ProductID,
+		VariantID:    &variant.ID,
+		VariantLabel: variant.Label,
+		MovementType: models.MovementAdjust,
+		Quantity:     variant.StockQuantity,
+	}
+
+	if previous == nil {
+		if variant.StockQuantity == 0 || variant.Status != "active" {
+			return nil
+		}
+		movement.MovementType = models.MovementReceive
+		movement.Note = "Initial stock"
+		return movement
+	}
+
+	switch {
+	case previous.Status != variant.Status && variant.Status == "active":
+		movement.Quantity = variant.StockQuantity
+		movement.Note = "Variant activated; its stock counts towards the product again"
+	case previous.Status != variant.Status:
+		movement.Quantity = -previous.StockQuantity
+		movement.Note = "Variant deactivated; its stock no longer counts towards the product"
+	case variant.Status != "active":
+		// Stock of an inactive variant does not count towards the product
+		return nil
+	default:
+		movement.Quantity = variant.StockQuantity - previous.StockQuantity
+		movement.Note = "Stock edited on the variant"
+	}
+
+	if movement.Quantity == 0 {
+		return nil
+	}
+	return movement
+}
+
+// getProductVariant retrieves a variant, checking that it belongs to the product
+func (s *ProductService) getProductVariant(productID, variantID uint) (*models.ProductVariant, error) {
+	variant, err := s.variantRepo.GetByID(variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if variant.ProductID != productID {
+		return nil, errors.New("variant not found")
+	}
+
+	return variant, nil
+}
+
+// CreateVariant adds a variant to a product
+// Once a product has active variants, its stock is the total of their stock.
+func (s *ProductService) CreateVariant(productID uint, req *ProductVariantRequest, operatorID uint) (*models.ProductVariant, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	variant := &models.ProductVariant{
+		ProductID: productID,
+		Status:    "active",
+	}
+
+	if err := s.applyVariantRequest(variant, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveVariant(variant, true, operatorID); err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+// UpdateVariant updates a variant's attributes, price override, stock and ordering
+func (s *ProductService) UpdateVariant(productID, variantID uint, req *ProductVariantRequest, operatorID uint) (*models.ProductVariant, error) {
+	variant, err := s.getProductVariant(productID, variantID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyVariantRequest(variant, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveVariant(variant, false, operatorID); err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+// SetVariantStatus sets a variant's status (active/inactive)
+// Inactive variants cannot be redeemed and do not count towards the product's stock.
+func (s *ProductService) SetVariantStatus(productID, variantID uint, status string, operatorID uint) error {
+	if status != "active" && status != "inactive" {
+		return errors.New("invalid status: must be 'active' or 'inactive'")
+	}
+
+	variant, err := s.getProductVariant(productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	variant.Status = status
+	return s.saveVariant(variant, false, operatorID)
+}
+
+// ListVariants lists all of a product's variants, including inactive ones
+func (s *ProductService) ListVariants(productID uint) ([]models.ProductVariant, error) {
+	if _, err := s.productRepo.GetByID(productID); err != nil {
+		return nil, err
+	}
+
+	return s.variantRepo.ListByProduct(productID)
+}
+
+// hasActiveVariants reports whether a product's stock is tracked per variant
+func hasActiveVariants(product *models.Product) bool {
+	for _, variant := range product.Variants {
+		if variant.Status == "active" {
+			return true
+		}
+	}
+	return false
+}
+
+// activeVariants drops inactive variants from a product before it is shown to employees
+func activeVariants(product *models.Product) {
+	variants := make([]models.ProductVariant, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		if variant.Status == "active" {
+			variants = append(variants, variant)
+		}
+	}
+	product.Variants = variants
+}
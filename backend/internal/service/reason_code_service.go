@@ -0,0 +1,152 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// reasonCodePattern restricts codes to upper-case letters, digits and underscores
+var reasonCodePattern = regexp.MustCompile(`^[A-Z0-9_]{1,50}$`)
+
+// normalizeReasonCode trims a code and upper-cases it
+func normalizeReasonCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// ReasonCodeService manages the reason code catalog
+type ReasonCodeService struct {
+	reasonCodeRepo *repository.ReasonCodeRepository
+}
+
+// NewReasonCodeService creates a new ReasonCodeService instance
+func NewReasonCodeService(reasonCodeRepo *repository.ReasonCodeRepository) *ReasonCodeService {
+	return &ReasonCodeService{
+		reasonCodeRepo: reasonCodeRepo,
+	}
+}
+
+// ReasonCodeRequest represents a request to create or update a reason code
+type ReasonCodeRequest struct {
+	Code         string `json:"code"`
+	LabelZh      string `json:"label_zh"`
+	LabelEn      string `json:"label_en"`
+	NoteRequired bool   `json:"note_required"`
+}
+
+// CreateReasonCode adds a reason code to the catalog
+func (s *ReasonCodeService) CreateReasonCode(req *ReasonCodeRequest) (*models.ReasonCode, error) {
+	code := normalizeReasonCode(req.Code)
+	if !reasonCodePattern.MatchString(code) {
+		return nil, errors.New("invalid code: use up to 50 letters, digits and underscores")
+	}
+
+	if req.LabelZh == "" || req.LabelEn == "" {
+		return nil, errors.New("both label_zh and label_en are required")
+	}
+
+	exists, err := s.reasonCodeRepo.ExistsByCode(code)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.New("reason code already exists")
+	}
+
+	reasonCode := &models.ReasonCode{
+		Code:         code,
+		LabelZh:      req.LabelZh,
+		LabelEn:      req.LabelEn,
+		NoteRequired: req.NoteRequired,
+		Status:       "active",
+	}
+
+	err = s.reasonCodeRepo.Create(reasonCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return reasonCode, nil
+}
+
+// ListReasonCodes lists reason codes with optional status filter
+func (s *ReasonCodeService) ListReasonCodes(status *string) ([]models.ReasonCode, error) {
+	return s.reasonCodeRepo.List(status)
+}
+
+// UpdateReasonCode updates a reason code's labels and note requirement
+// The code itself cannot change, since reports group transactions by it.
+func (s *ReasonCodeService) UpdateReasonCode(reasonCodeID uint, req *ReasonCodeRequest) (*models.ReasonCode, error) {
+	reasonCode, err := s.reasonCodeRepo.GetByID(reasonCodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Code != "" && normalizeReasonCode(req.Code) != reasonCode.Code {
+		return nil, errors.New("code cannot be changed")
+	}
+
+	if req.LabelZh == "" || req.LabelEn == "" {
+		return nil, errors.New("both label_zh and label_en are required")
+	}
+
+	reasonCode.LabelZh = req.LabelZh
+	reasonCode.LabelEn = req.LabelEn
+	reasonCode.NoteRequired = req.NoteRequired
+
+	err = s.reasonCodeRepo.Update(reasonCode)
+	if err != nil {
+		return nil, err
+	}
+
+	return reasonCode, nil
+}
+
+// SetReasonCodeStatus sets a reason code's status (active/inactive)
+// Inactive codes stay on past transactions but cannot be used for new ones.
+func (s *ReasonCodeService) SetReasonCodeStatus(reasonCodeID uint, status string) error {
+	if status != "active" && status != "inactive" {
+		return errors.New("invalid status: must be 'active' or 'inactive'")
+	}
+
+	if _, err := s.reasonCodeRepo.GetByID(reasonCodeID); err != nil {
+		return err
+	}
+
+	return s.reasonCodeRepo.UpdateStatus(reasonCodeID, status)
+}
+
+// resolveReason checks a reason code and note and returns the code's ID and the reason text to record
+// Without a code the note is the reason and is required. With a code the note is optional
+// unless the code requires one; an empty note records the code's Chinese label.
+func resolveReason(reasonCodeRepo *repository.ReasonCodeRepository, code string, note string) (*uint, string, error) {
+	code = normalizeReasonCode(code)
+	if code == "" {
+		if note == "" {
+			return nil, "", errors.New("reason is required")
+		}
+		return nil, note, nil
+	}
+
+	reasonCode, err := reasonCodeRepo.GetByCode(code)
+	if err != nil {
+		return nil, "", fmt.Errorf("unknown reason code: %s", code)
+	}
+
+	if reasonCode.Status != "active" {
+		return nil, "", fmt.Errorf("reason code %s is inactive", code)
+	}
+
+	if reasonCode.NoteRequired && note == "" {
+		return nil, "", fmt.Errorf("reason code %s requires a note", code)
+	}
+
+	if note == "" {
+		note = reasonCode.LabelZh
+	}
+
+	return &reasonCode.ID, note, nil
+}
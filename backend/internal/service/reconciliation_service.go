@@ -0,0 +1,310 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReconciliationService checks the points_transactions ledger against user_wallets per wallet,
+// and users.points_balance against the total of the user's wallets
+type ReconciliationService struct {
+	userRepo              *repository.UserRepository
+	pointsTransactionRepo *repository.PointsTransactionRepository
+	walletRepo            *repository.UserWalletRepository
+	db                    *gorm.DB
+}
+
+// NewReconciliationService creates a new ReconciliationService instance
+func NewReconciliationService(
+	userRepo *repository.UserRepository,
+	pointsTransactionRepo *repository.PointsTransactionRepository,
+	walletRepo *repository.UserWalletRepository,
+	db *gorm.DB,
+) *ReconciliationService {
+	return &ReconciliationService{
+		userRepo:              userRepo,
+		pointsTransactionRepo: pointsTransactionRepo,
+		walletRepo:            walletRepo,
+		db:                    db,
+	}
+}
+
+// BalanceMismatch describes a wallet whose stored balance differs from the sum of its ledger entries
+type BalanceMismatch struct {
+	UserID                  uint   `json:"user_id"`
+	UserEmail               string `json:"user_email"`
+	Wallet                  string `json:"wallet"`
+	WalletBalance           int    `json:"wallet_balance"`
+	LedgerBalance           int    `json:"ledger_balance"`
+	Difference              int    `json:"difference"`
+	Repaired                bool   `json:"repaired"`
+	CorrectionTransactionID *uint  `json:"correction_transaction_id,omitempty"`
+}
+
+// TotalMismatch describes a user whose points_balance differs from the total of their wallets
+type TotalMismatch struct {
+	UserID        uint   `json:"user_id"`
+	UserEmail     string `json:"user_email"`
+	PointsBalance int    `json:"points_balance"`
+	WalletTotal   int    `json:"wallet_total"`
+	Difference    int    `json:"difference"`
+	Repaired      bool   `json:"repaired"`
+}
+
+// ChainBreak describes a transaction whose balance_after does not follow from the previous entry
+type ChainBreak struct {
+	UserID          uint   `json:"user_id"`
+	UserEmail       string `json:"user_email"`
+	TransactionID   uint   `json:"transaction_id"`
+	ExpectedBalance int    `json:"expected_balance"`
+	RecordedBalance int    `json:"recorded_balance"`
+}
+
+// ReconciliationReport is the result of a reconciliation run
+type ReconciliationReport struct {
+	CheckedUsers        int               `json:"checked_users"`
+	CheckedTransactions int               `json:"checked_transactions"`
+	Mismatches          []BalanceMismatch `json:"mismatches"`
+	TotalMismatches     []TotalMismatch   `json:"total_mismatches"`
+	ChainBreaks         []ChainBreak      `json:"chain_breaks"`
+	Repaired            int               `json:"repaired"`
+	RepairedTotals      int               `json:"repaired_totals"`
+	GeneratedAt         time.Time         `json:"generated_at"`
+}
+
+// HasIssues reports whether the ledger still has unrepaired problems
+func (r *ReconciliationReport) HasIssues() bool {
+	return len(r.ChainBreaks) > 0 ||
+		r.Repaired < len(r.Mismatches) ||
+		r.RepairedTotals < len(r.TotalMismatches)
+}
+
+// Reconcile compares every wallet balance with its ledger entries, every points_balance with the
+// total of the user's wallets, and checks each balance_after chain
+// When repair is set, each mismatched wallet gets an "adjustment" transaction in that wallet for
+// the difference, so the ledger sums to the wallet balance again, and points_balance is reset to
+// the wallet total. Wallet balances are what redemptions spend, so they are taken as correct.
+// Chain breaks are only reported, since existing ledger entries are never rewritten.
+func (s *ReconciliationService) Reconcile(repair bool, operatorID *uint) (*ReconciliationReport, error) {
+	users, err := s.userRepo.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconciliationReport{
+		Mismatches:      []BalanceMismatch{},
+		TotalMismatches: []TotalMismatch{},
+		ChainBreaks:     []ChainBreak{},
+		GeneratedAt:     time.Now(),
+	}
+
+	userIDs := make([]uint, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	walletBalances, err := s.walletRepo.GetBalances(s.db, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		transactions, err := s.pointsTransactionRepo.GetByUserIDInLedgerOrder(user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		report.CheckedUsers++
+		report.CheckedTransactions += len(transactions)
+
+		ledgerBalances := make(map[string]int, len(models.WalletTypes))
+		previousBalance := 0
+		for _, transaction := range transactions {
+			ledgerBalances[transaction.Wallet] += transaction.Amount
+
+			expected := previousBalance + transaction.Amount
+			if transaction.BalanceAfter != expected {
+				report.ChainBreaks = append(report.ChainBreaks, ChainBreak{
+					UserID:          user.ID,
+					UserEmail:       user.Email,
+					TransactionID:   transaction.ID,
+					ExpectedBalance: expected,
+					RecordedBalance: transaction.BalanceAfter,
+				})
+			}
+
+			// Continue from the recorded value so one bad entry is reported once
+			previousBalance = transaction.BalanceAfter
+		}
+
+		var mismatches []BalanceMismatch
+		walletTotal := 0
+		for _, wallet := range models.WalletTypes {
+			balance := walletBalances[user.ID][wallet]
+			walletTotal += balance
+
+			if ledgerBalances[wallet] != balance {
+				mismatches = append(mismatches, BalanceMismatch{
+					UserID:        user.ID,
+					UserEmail:     user.Email,
+					Wallet:        wallet,
+					WalletBalance: balance,
+					LedgerBalance: ledgerBalances[wallet],
+					Difference:    balance - ledgerBalances[wallet],
+				})
+			}
+		}
+
+		var totalMismatch *TotalMismatch
+		if user.PointsBalance != walletTotal {
+			totalMismatch = &TotalMismatch{
+				UserID:        user.ID,
+				UserEmail:     user.Email,
+				PointsBalance: user.PointsBalance,
+				WalletTotal:   walletTotal,
+				Difference:    walletTotal - user.PointsBalance,
+			}
+		}
+
+		if repair && (len(mismatches) > 0 || totalMismatch != nil) {
+			result, err := s.repairBalances(user.ID, operatorID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to repair balances for user %d: %w", user.ID, err)
+			}
+
+			for i := range mismatches {
+				if correction, ok := result.corrections[mismatches[i].Wallet]; ok {
+					mismatches[i].Repaired = true
+					mismatches[i].CorrectionTransactionID = &correction.ID
+					report.Repaired++
+				}
+			}
+			if totalMismatch != nil && result.totalReset {
+				totalMismatch.Repaired = true
+				report.RepairedTotals++
+			}
+		}
+
+		report.Mismatches = append(report.Mismatches, mismatches...)
+		if totalMismatch != nil {
+			report.TotalMismatches = append(report.TotalMismatches, *totalMismatch)
+		}
+	}
+
+	return report, nil
+}
+
+// balanceRepair is what repairBalances changed for one user
+type balanceRepair struct {
+	corrections map[string]*models.PointsTransaction // Adjustment written per wallet
+	totalReset  bool                                 // Whether points_balance was reset to the wallet total
+}
+
+// repairBalances writes an adjustment transaction for each wallet whose ledger does not sum to its
+// balance, and resets points_balance to the total of the user's wallets
+// Balances and ledger sums are re-read under the user's row lock, so only differences that still
+// exist are corrected. Wallet balances are left untouched: the entries bring the ledger in line
+// with them rather than moving any points.
+func (s *ReconciliationService) repairBalances(userID uint, operatorID *uint) (*balanceRepair, error) {
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if len(lockedUsers) == 0 {
+		tx.Rollback()
+		return nil, errors.New("user not found")
+	}
+	user := lockedUsers[0]
+
+	walletBalances, err := s.walletRepo.GetBalances(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	ledgerBalances, err := s.pointsTransactionRepo.SumAmountByWallet(tx, userID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	ledgerTotal := 0
+	for _, amount := range ledgerBalances {
+		ledgerTotal += amount
+	}
+
+	result := &balanceRepair{
+		corrections: make(map[string]*models.PointsTransaction),
+	}
+
+	// Create a correction transaction record per mismatched wallet
+	runningBalance := ledgerTotal
+	walletTotal := 0
+	for _, wallet := range models.WalletTypes {
+		balance := walletBalances[userID][wallet]
+		walletTotal += balance
+
+		difference := balance - ledgerBalances[wallet]
+		if difference == 0 {
+			continue
+		}
+
+		runningBalance += difference
+		transaction := &models.PointsTransaction{
+			UserID:          userID,
+			TransactionType: "adjustment",
+			Wallet:          wallet,
+			Amount:          difference,
+			BalanceAfter:    runningBalance,
+			Reason: fmt.Sprintf("账本对账修正 / Ledger reconciliation correction (%s wallet: %d, ledger: %d)",
+				wallet, balance, ledgerBalances[wallet]),
+			OperatorID:     operatorID,
+			RelatedOrderID: nil,
+		}
+
+		err = tx.Create(transaction).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		result.corrections[wallet] = transaction
+	}
+
+	// points_balance is the total of the user's wallets
+	if user.PointsBalance != walletTotal {
+		err = tx.Model(&models.User{}).
+			Where("id = ?", userID).
+			Update("points_balance", walletTotal).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		result.totalReset = true
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -1,22 +1,31 @@
 package service
 
 import (
+	"awsome-shop/internal/events"
 	"awsome-shop/internal/models"
 	"awsome-shop/internal/repository"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // RedemptionService handles product redemption operations
 type RedemptionService struct {
 	userRepo              *repository.UserRepository
 	productRepo           *repository.ProductRepository
+	variantRepo           *repository.ProductVariantRepository
 	orderRepo             *repository.RedemptionOrderRepository
 	pointsTransactionRepo *repository.PointsTransactionRepository
+	walletRepo            *repository.UserWalletRepository
+	movementRepo          *repository.InventoryMovementRepository
+	campaignService       *CampaignService
+	events                *events.Bus
 	db                    *gorm.DB
 }
 
@@ -24,27 +33,41 @@ type RedemptionService struct {
 func NewRedemptionService(
 	userRepo *repository.UserRepository,
 	productRepo *repository.ProductRepository,
+	variantRepo *repository.ProductVariantRepository,
 	orderRepo *repository.RedemptionOrderRepository,
 	pointsTransactionRepo *repository.PointsTransactionRepository,
+	walletRepo *repository.UserWalletRepository,
+	movementRepo *repository.InventoryMovementRepository,
+	campaignService *CampaignService,
+	eventBus *events.Bus,
 	db *gorm.DB,
 ) *RedemptionService {
 	return &RedemptionService{
 		userRepo:              userRepo,
 		productRepo:           productRepo,
+		variantRepo:           variantRepo,
 		orderRepo:             orderRepo,
 		pointsTransactionRepo: pointsTransactionRepo,
+		walletRepo:            walletRepo,
+		movementRepo:          movementRepo,
+		campaignService:       campaignService,
+		events:                eventBus,
 		db:                    db,
 	}
 }
 
 // RedeemProductRequest represents a request to redeem a product
 type RedeemProductRequest struct {
-	ProductID uint `json:"product_id" binding:"required"`
+	ProductID uint  `json:"product_id" binding:"required"`
+	VariantID *uint `json:"variant_id"` // Required when the product has variants
 }
 
 // RedeemProduct processes a product redemption
-// This includes: points validation, stock validation, points deduction, stock reduction, order creation
-func (s *RedemptionService) RedeemProduct(userID uint, productID uint) (*models.RedemptionOrder, error) {
+// This includes: campaign pricing, points validation, stock validation, points deduction, stock reduction, order creation
+// The price is drawn from the product's accepted wallets in walletSpendOrder, writing one
+// redemption transaction per wallet used. Products with variants are redeemed one variant at a
+// time: the variant's row is locked, its own stock and price apply, and its label is kept on the order.
+func (s *RedemptionService) RedeemProduct(userID uint, productID uint, variantID *uint) (*models.RedemptionOrder, error) {
 	// Start transaction
 	tx := s.db.Begin()
 	if tx.Error != nil {
@@ -59,7 +82,7 @@ func (s *RedemptionService) RedeemProduct(userID uint, productID uint) (*models.
 
 	// Get user with lock
 	var user models.User
-	err := tx.Clauses(gorm.Locking{Strength: "UPDATE"}).First(&user, userID).Error
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error
 	if err != nil {
 		tx.Rollback()
 		return nil, errors.New("user not found")
@@ -73,63 +96,113 @@ func (s *RedemptionService) RedeemProduct(userID uint, productID uint) (*models.
 
 	// Get product with lock (for stock management)
 	var product models.Product
-	err = tx.Clauses(gorm.Locking{Strength: "UPDATE"}).First(&product, productID).Error
+	err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&product, productID).Error
 	if err != nil {
 		tx.Rollback()
 		return nil, errors.New("product not found")
 	}
 
-	// Validate product is active
-	if product.Status != "active" {
+	// Resolve the variant being redeemed, if the product has any
+	variantCount, err := s.variantRepo.CountActiveByProduct(tx, productID)
+	if err != nil {
 		tx.Rollback()
-		return nil, errors.New("product is not available")
+		return nil, err
 	}
 
-	// Validate stock
-	if product.StockQuantity <= 0 {
+	var variant *models.ProductVariant
+	if variantID != nil {
+		variant, err = s.variantRepo.GetByIDWithLock(tx, *variantID)
+		if err != nil {
+			tx.Rollback()
+			return nil, errors.New("variant not found")
+		}
+	}
+
+	// Validate the product and variant can be redeemed now
+	err = checkRedeemable(&product, variant, variantCount, time.Now())
+	if err != nil {
 		tx.Rollback()
-		return nil, errors.New("product is out of stock")
+		return nil, err
 	}
 
-	// Validate user has sufficient points
-	if user.PointsBalance < product.PointsRequired {
+	// Apply the best active campaign at checkout
+	var price *CampaignPrice
+	if variant != nil {
+		price, err = s.campaignService.PriceForVariant(&product, variant, time.Now())
+	} else {
+		price, err = s.campaignService.PriceForProduct(&product, time.Now())
+	}
+	if err != nil {
 		tx.Rollback()
-		return nil, errors.New("insufficient points")
+		return nil, err
+	}
+
+	walletBalances, err := s.walletRepo.GetBalances(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return nil, err
 	}
 
-	// Calculate new balance
-	newBalance := user.PointsBalance - product.PointsRequired
+	// Validate user has sufficient points in the accepted wallets
+	spends, err := planWalletSpend(product.AcceptedWallets, walletBalances[userID], price.Price)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Calculate new balance (campaign bonus points are credited after the redemption)
+	newBalance := user.PointsBalance - price.Price
+	finalBalance := newBalance + price.BonusPoints
 
 	// Update user points balance
 	err = tx.Model(&models.User{}).
 		Where("id = ?", userID).
-		Update("points_balance", newBalance).Error
+		Update("points_balance", finalBalance).Error
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
 	// Decrement product stock
-	err = tx.Model(&models.Product{}).
-		Where("id = ?", productID).
-		Update("stock_quantity", gorm.Expr("stock_quantity - ?", 1)).Error
+	err = s.productRepo.AdjustStock(tx, productID, -1)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
+	if variant != nil {
+		err = s.variantRepo.AdjustStock(tx, variant.ID, -1)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	// Generate order number
-	orderNumber := s.generateOrderNumber(userID, productID)
+	orderNumber, err := s.generateOrderNumber(userID, productID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 
 	// Create redemption order
 	order := &models.RedemptionOrder{
-		OrderNumber:        orderNumber,
-		UserID:             userID,
-		ProductID:          productID,
-		ProductName:        product.Name,
-		PointsCost:         product.PointsRequired,
-		PointsBalanceAfter: newBalance,
-		Status:             "preparing",
+		OrderNumber:         orderNumber,
+		UserID:              userID,
+		ProductID:           productID,
+		ProductName:         product.LocalizedName(user.PreferredLanguage), // Snapshot of the name the user was shown
+		PointsCost:          price.Price,
+		PointsBalanceAfter:  finalBalance,
+		OriginalPointsCost:  price.OriginalPrice,
+		CampaignBonusPoints: price.BonusPoints,
+		Status:              "preparing",
+	}
+	if variant != nil {
+		order.VariantID = &variant.ID
+		order.VariantLabel = variant.Label
+	}
+	if price.Campaign != nil {
+		order.CampaignID = &price.Campaign.ID
 	}
 
 	err = tx.Create(order).Error
@@ -138,41 +211,144 @@ func (s *RedemptionService) RedeemProduct(userID uint, productID uint) (*models.
 		return nil, err
 	}
 
-	// Create points transaction record
-	transaction := &models.PointsTransaction{
-		UserID:          userID,
-		TransactionType: "redemption",
-		Amount:          -product.PointsRequired,
-		BalanceAfter:    newBalance,
-		Reason:          fmt.Sprintf("兑换商品: %s / Redeem product: %s", product.Name, product.Name),
-		OperatorID:      nil, // User operation
-		RelatedOrderID:  &order.ID,
+	// Record the stock taken by this order
+	movement := &models.InventoryMovement{
+		ProductID:      productID,
+		MovementType:   models.MovementRedeem,
+		Quantity:       -1,
+		RelatedOrderID: &order.ID,
+	}
+	if variant != nil {
+		movement.VariantID = &variant.ID
+		movement.VariantLabel = variant.Label
 	}
 
-	err = tx.Create(transaction).Error
+	err = s.movementRepo.Record(tx, movement)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
+	// Create a points transaction record for each wallet drawn from
+	runningBalance := user.PointsBalance
+	for _, spend := range spends {
+		runningBalance -= spend.Amount
+
+		err = s.walletRepo.AdjustBalance(tx, userID, spend.Wallet, -spend.Amount)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		transaction := &models.PointsTransaction{
+			UserID:          userID,
+			TransactionType: "redemption",
+			Wallet:          spend.Wallet,
+			Amount:          -spend.Amount,
+			BalanceAfter:    runningBalance,
+			Reason:          fmt.Sprintf("兑换商品: %s / Redeem product: %s", product.LocalizedName(models.LanguageZh), product.LocalizedName(models.LanguageEn)),
+			OperatorID:      nil, // User operation
+			RelatedOrderID:  &order.ID,
+		}
+
+		err = tx.Create(transaction).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	// Credit campaign bonus points to the benefit wallet
+	if price.BonusPoints > 0 {
+		err = s.walletRepo.AdjustBalance(tx, userID, models.WalletBenefit, price.BonusPoints)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+
+		bonusTransaction := &models.PointsTransaction{
+			UserID:          userID,
+			TransactionType: "grant",
+			Wallet:          models.WalletBenefit,
+			Amount:          price.BonusPoints,
+			BalanceAfter:    finalBalance,
+			Reason:          fmt.Sprintf("活动奖励 / Campaign bonus: %s", price.Campaign.Name),
+			OperatorID:      nil, // System operation
+			RelatedOrderID:  &order.ID,
+		}
+
+		err = tx.Create(bonusTransaction).Error
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
 	// Commit transaction
 	err = tx.Commit().Error
 	if err != nil {
 		return nil, err
 	}
 
+	publishLowStock(s.events, &product, product.StockQuantity, movement.ProductStockAfter)
+
 	// Load relationships for response
+	user.PointsBalance = finalBalance
 	order.User = user
 	order.Product = product
+	order.Variant = variant
+	order.Campaign = price.Campaign
 
 	return order, nil
 }
 
+// checkRedeemable validates that a product, and the variant being redeemed if any, can be
+// redeemed at now
+// variant is nil when none was requested; activeVariants is the product's active variant count.
+// The availability window is checked here because the availability job may not have run yet.
+func checkRedeemable(product *models.Product, variant *models.ProductVariant, activeVariants int64, now time.Time) error {
+	if product.Status != "active" || !product.IsAvailableAt(now) {
+		return errors.New("product is not available")
+	}
+
+	if product.StockQuantity <= 0 {
+		return errors.New("product is out of stock")
+	}
+
+	if variant == nil {
+		if activeVariants > 0 {
+			return errors.New("variant_id is required for this product")
+		}
+		return nil
+	}
+
+	if variant.ProductID != product.ID {
+		return errors.New("variant not found")
+	}
+
+	if variant.Status != "active" {
+		return errors.New("variant is not available")
+	}
+
+	if variant.StockQuantity <= 0 {
+		return errors.New("variant is out of stock")
+	}
+
+	return nil
+}
+
 // generateOrderNumber generates a unique order number
-// Format: RD + timestamp + userID + productID
-func (s *RedemptionService) generateOrderNumber(userID, productID uint) string {
+// Format: RD + timestamp + userID + productID + random suffix
+// The suffix keeps numbers apart when the same user redeems two variants of a product within
+// one second.
+func (s *RedemptionService) generateOrderNumber(userID, productID uint) (string, error) {
+	random := make([]byte, 4)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
 	timestamp := time.Now().Format("20060102150405")
-	return fmt.Sprintf("RD%s%d%d", timestamp, userID, productID)
+	return fmt.Sprintf("RD%s%d%d%s", timestamp, userID, productID, strings.ToUpper(hex.EncodeToString(random))), nil
 }
 
 // GetRedemptionHistory gets a user's redemption history
@@ -282,7 +458,8 @@ func (s *RedemptionService) CountOrdersByStatus(status string) (int64, error) {
 }
 
 // ValidateRedemption validates if a redemption can proceed
-func (s *RedemptionService) ValidateRedemption(userID uint, productID uint) error {
+// It applies the same product and variant checks as RedeemProduct (see checkRedeemable).
+func (s *RedemptionService) ValidateRedemption(userID uint, productID uint, variantID *uint) error {
 	// Get user
 	user, err := s.userRepo.GetByID(userID)
 	if err != nil {
@@ -299,16 +476,41 @@ func (s *RedemptionService) ValidateRedemption(userID uint, productID uint) erro
 		return errors.New("product not found")
 	}
 
-	if product.Status != "active" {
-		return errors.New("product is not available")
+	variantCount, err := s.variantRepo.CountActiveByProduct(s.db, productID)
+	if err != nil {
+		return err
 	}
 
-	if product.StockQuantity <= 0 {
-		return errors.New("product is out of stock")
+	var variant *models.ProductVariant
+	if variantID != nil {
+		variant, err = s.variantRepo.GetByID(*variantID)
+		if err != nil {
+			return errors.New("variant not found")
+		}
+	}
+
+	now := time.Now()
+	if err := checkRedeemable(product, variant, variantCount, now); err != nil {
+		return err
+	}
+
+	var price *CampaignPrice
+	if variant != nil {
+		price, err = s.campaignService.PriceForVariant(product, variant, now)
+	} else {
+		price, err = s.campaignService.PriceForProduct(product, now)
+	}
+	if err != nil {
+		return err
+	}
+
+	walletBalances, err := s.walletRepo.GetBalances(s.db, []uint{user.ID})
+	if err != nil {
+		return err
 	}
 
-	if user.PointsBalance < product.PointsRequired {
-		return errors.New("insufficient points")
+	if _, err := planWalletSpend(product.AcceptedWallets, walletBalances[user.ID], price.Price); err != nil {
+		return err
 	}
 
 	return nil
@@ -0,0 +1,435 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// segmentGrantChunkSize is the number of users granted per database transaction
+	segmentGrantChunkSize = 200
+	// segmentGrantStaleAfter is how long a running job may go without progress before it can be resumed
+	segmentGrantStaleAfter = 10 * time.Minute
+)
+
+// errGrantJobTakenOver is returned when another runner recorded a chunk's progress first
+var errGrantJobTakenOver = errors.New("segment grant job was taken over by another runner")
+
+// SegmentService manages saved user segments and bulk grants targeted at them
+type SegmentService struct {
+	segmentRepo   *repository.UserSegmentRepository
+	userRepo      *repository.UserRepository
+	pointsService *PointsService
+	db            *gorm.DB
+}
+
+// NewSegmentService creates a new SegmentService instance
+func NewSegmentService(
+	segmentRepo *repository.UserSegmentRepository,
+	userRepo *repository.UserRepository,
+	pointsService *PointsService,
+	db *gorm.DB,
+) *SegmentService {
+	return &SegmentService{
+		segmentRepo:   segmentRepo,
+		userRepo:      userRepo,
+		pointsService: pointsService,
+		db:            db,
+	}
+}
+
+// SegmentRequest represents a request to create or update a segment
+type SegmentRequest struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	UserStatus  string     `json:"user_status"` // any, active or inactive; defaults to active
+	Department  string     `json:"department"`
+	Location    string     `json:"location"`
+	Role        string     `json:"role"`
+	JoinedFrom  *time.Time `json:"joined_from"`
+	JoinedTo    *time.Time `json:"joined_to"`
+}
+
+// applySegmentRequest validates a segment request and copies it onto segment
+func (s *SegmentService) applySegmentRequest(segment *models.UserSegment, req *SegmentRequest) error {
+	if req.Name == "" {
+		return errors.New("name is required")
+	}
+
+	userStatus := req.UserStatus
+	if userStatus == "" {
+		userStatus = "active"
+	}
+	if userStatus != "any" && userStatus != "active" && userStatus != "inactive" {
+		return errors.New("invalid user_status: must be 'any', 'active' or 'inactive'")
+	}
+
+	if req.Role != "" && req.Role != "employee" && req.Role != "admin" {
+		return errors.New("invalid role: must be 'employee' or 'admin'")
+	}
+
+	if req.JoinedFrom != nil && req.JoinedTo != nil && req.JoinedFrom.After(*req.JoinedTo) {
+		return errors.New("joined_from cannot be after joined_to")
+	}
+
+	exists, err := s.segmentRepo.ExistsByName(req.Name, segment.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return errors.New("segment name already exists")
+	}
+
+	segment.Name = req.Name
+	segment.Description = req.Description
+	segment.UserStatus = userStatus
+	segment.Department = req.Department
+	segment.Location = req.Location
+	segment.Role = req.Role
+	segment.JoinedFrom = req.JoinedFrom
+	segment.JoinedTo = req.JoinedTo
+	return nil
+}
+
+// CreateSegment saves a new segment
+func (s *SegmentService) CreateSegment(req *SegmentRequest, operatorID uint) (*models.UserSegment, error) {
+	segment := &models.UserSegment{CreatedBy: &operatorID}
+	if err := s.applySegmentRequest(segment, req); err != nil {
+		return nil, err
+	}
+
+	err := s.segmentRepo.Create(segment)
+	if err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// ListSegments lists all saved segments
+func (s *SegmentService) ListSegments() ([]models.UserSegment, error) {
+	return s.segmentRepo.List()
+}
+
+// GetSegmentByID gets a segment by ID
+func (s *SegmentService) GetSegmentByID(segmentID uint) (*models.UserSegment, error) {
+	return s.segmentRepo.GetByID(segmentID)
+}
+
+// UpdateSegment replaces a segment's name and filters
+// Jobs already running pick up the new filters from their next run.
+func (s *SegmentService) UpdateSegment(segmentID uint, req *SegmentRequest) (*models.UserSegment, error) {
+	segment, err := s.segmentRepo.GetByID(segmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applySegmentRequest(segment, req); err != nil {
+		return nil, err
+	}
+
+	err = s.segmentRepo.Update(segment)
+	if err != nil {
+		return nil, err
+	}
+
+	return segment, nil
+}
+
+// DeleteSegment deletes a segment that has never been used for a grant
+func (s *SegmentService) DeleteSegment(segmentID uint) error {
+	if _, err := s.segmentRepo.GetByID(segmentID); err != nil {
+		return err
+	}
+
+	used, err := s.segmentRepo.ExistsGrantJobForSegment(segmentID)
+	if err != nil {
+		return err
+	}
+	if used {
+		return errors.New("segment has grant jobs and cannot be deleted")
+	}
+
+	return s.segmentRepo.Delete(segmentID)
+}
+
+// segmentSkipReason explains why a segment member is left out of a grant, or returns "" if they are eligible
+// The rules match single grants: no inactive users, no self-grants, and grants to admins
+// need a second approver, which a bulk grant cannot provide.
+func segmentSkipReason(user *models.User, operatorID uint) string {
+	if !user.IsActive {
+		return "user is inactive"
+	}
+	if user.ID == operatorID {
+		return "cannot grant points to yourself"
+	}
+	if user.Role == "admin" {
+		return "grants to admins need a second approver"
+	}
+	return ""
+}
+
+// SegmentPreviewUser is one matched user in a segment preview
+type SegmentPreviewUser struct {
+	ID         uint   `json:"id"`
+	FullName   string `json:"full_name"`
+	Email      string `json:"email"`
+	Department string `json:"department"`
+	Location   string `json:"location"`
+	Role       string `json:"role"`
+	SkipReason string `json:"skip_reason,omitempty"`
+}
+
+// SegmentPreview lists who a grant to a segment would reach and what it would cost
+type SegmentPreview struct {
+	SegmentID     uint                 `json:"segment_id"`
+	Amount        int                  `json:"amount"`
+	MatchedUsers  int                  `json:"matched_users"`
+	EligibleUsers int                  `json:"eligible_users"`
+	TotalCost     int                  `json:"total_cost"`
+	Users         []SegmentPreviewUser `json:"users"`
+}
+
+// PreviewSegment resolves a segment's current members and the cost of granting each of them amount
+func (s *SegmentService) PreviewSegment(segmentID uint, amount int, operatorID uint) (*SegmentPreview, error) {
+	if amount < 0 {
+		return nil, errors.New("amount cannot be negative")
+	}
+
+	segment, err := s.segmentRepo.GetByID(segmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := s.userRepo.ListBySegment(segment, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &SegmentPreview{
+		SegmentID:    segment.ID,
+		Amount:       amount,
+		MatchedUsers: len(users),
+		Users:        make([]SegmentPreviewUser, 0, len(users)),
+	}
+
+	for i := range users {
+		user := &users[i]
+		skipReason := segmentSkipReason(user, operatorID)
+		if skipReason == "" {
+			preview.EligibleUsers++
+		}
+
+		preview.Users = append(preview.Users, SegmentPreviewUser{
+			ID:         user.ID,
+			FullName:   user.FullName,
+			Email:      user.Email,
+			Department: user.Department,
+			Location:   user.Location,
+			Role:       user.Role,
+			SkipReason: skipReason,
+		})
+	}
+	preview.TotalCost = preview.EligibleUsers * amount
+
+	return preview, nil
+}
+
+// SegmentGrantRequest represents a request to grant points to every user in a segment
+type SegmentGrantRequest struct {
+	Wallet     string `json:"wallet"`
+	Amount     int    `json:"amount"` // Points per user
+	ReasonCode string `json:"reason_code"`
+	Reason     string `json:"reason"`
+}
+
+// StartGrantJob creates a grant job for a segment and starts applying it in the background
+// Progress can be followed through GetGrantJob.
+func (s *SegmentService) StartGrantJob(segmentID uint, req *SegmentGrantRequest, operatorID uint) (*models.SegmentGrantJob, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be greater than 0")
+	}
+
+	reasonCodeID, reason, err := resolveReason(s.pointsService.reasonCodeRepo, req.ReasonCode, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	wallet, err := normalizeWallet(req.Wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := s.PreviewSegment(segmentID, req.Amount, operatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if preview.EligibleUsers == 0 {
+		return nil, errors.New("segment has no users eligible for a grant")
+	}
+
+	job := &models.SegmentGrantJob{
+		SegmentID:    segmentID,
+		Wallet:       wallet,
+		Amount:       req.Amount,
+		Reason:       reason,
+		ReasonCodeID: reasonCodeID,
+		Status:       "pending",
+		TotalUsers:   preview.MatchedUsers,
+		OperatorID:   operatorID,
+	}
+
+	err = s.segmentRepo.CreateGrantJob(job)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.startGrantJob(job.ID); err != nil {
+		return nil, err
+	}
+
+	return s.segmentRepo.GetGrantJobByID(job.ID)
+}
+
+// ResumeGrantJob restarts a failed job, or a running job that has stopped making progress
+// The job continues after the last committed chunk, so nobody is granted twice.
+func (s *SegmentService) ResumeGrantJob(jobID uint) (*models.SegmentGrantJob, error) {
+	job, err := s.segmentRepo.GetGrantJobByID(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == "completed" {
+		return nil, errors.New("grant job has already completed")
+	}
+
+	claimed, err := s.startGrantJob(job.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errors.New("grant job is still running")
+	}
+
+	return s.segmentRepo.GetGrantJobByID(job.ID)
+}
+
+// GetGrantJob gets a grant job and its progress
+func (s *SegmentService) GetGrantJob(jobID uint) (*models.SegmentGrantJob, error) {
+	return s.segmentRepo.GetGrantJobByID(jobID)
+}
+
+// ListGrantJobs lists grant jobs, optionally for one segment
+func (s *SegmentService) ListGrantJobs(segmentID *uint) ([]models.SegmentGrantJob, error) {
+	return s.segmentRepo.ListGrantJobs(segmentID)
+}
+
+// startGrantJob claims a job and processes it in its own goroutine
+// It reports false if the job is held by another runner.
+func (s *SegmentService) startGrantJob(jobID uint) (bool, error) {
+	claimed, err := s.segmentRepo.ClaimGrantJob(jobID, time.Now().Add(-segmentGrantStaleAfter))
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	go s.processGrantJob(jobID)
+	return true, nil
+}
+
+// processGrantJob applies a claimed job chunk by chunk until every segment member is processed
+// The segment is re-read on each run, so members added since the job was created are included.
+func (s *SegmentService) processGrantJob(jobID uint) {
+	job, err := s.segmentRepo.GetGrantJobByID(jobID)
+	if err != nil {
+		log.Printf("Failed to load segment grant job %d: %v", jobID, err)
+		return
+	}
+
+	for {
+		users, err := s.userRepo.ListBySegment(&job.Segment, job.LastUserID, segmentGrantChunkSize)
+		if err == nil && len(users) > 0 {
+			err = s.applyGrantChunk(job, users)
+		}
+
+		if errors.Is(err, errGrantJobTakenOver) {
+			log.Printf("Segment grant job %d was taken over by another runner after user %d", job.ID, job.LastUserID)
+			return
+		}
+
+		if err != nil {
+			log.Printf("Segment grant job %d failed after user %d: %v", job.ID, job.LastUserID, err)
+			if err := s.segmentRepo.FinishGrantJob(job.ID, "failed", truncateError(err)); err != nil {
+				log.Printf("Failed to mark segment grant job %d as failed: %v", job.ID, err)
+			}
+			return
+		}
+
+		if len(users) == 0 {
+			if err := s.segmentRepo.FinishGrantJob(job.ID, "completed", ""); err != nil {
+				log.Printf("Failed to mark segment grant job %d as completed: %v", job.ID, err)
+			}
+			return
+		}
+	}
+}
+
+// applyGrantChunk grants one chunk of users and records the job's progress in the same transaction
+func (s *SegmentService) applyGrantChunk(job *models.SegmentGrantJob, users []models.User) error {
+	progress := *job
+
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	for i := range users {
+		user := &users[i]
+
+		if segmentSkipReason(user, job.OperatorID) != "" {
+			progress.SkippedUsers++
+		} else {
+			_, err := s.pointsService.grantPointsInTx(tx, user.ID, job.Wallet, job.Amount, job.ReasonCodeID, job.Reason, job.OperatorID, nil)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+			progress.GrantedUsers++
+			progress.TotalGranted += job.Amount
+		}
+
+		progress.LastUserID = user.ID
+	}
+
+	// Another runner may have taken over a stale job and granted this chunk already
+	updated, err := s.segmentRepo.UpdateGrantJobProgress(tx, &progress, job.LastUserID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if !updated {
+		tx.Rollback()
+		return errGrantJobTakenOver
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
+	if err != nil {
+		return err
+	}
+
+	*job = progress
+	return nil
+}
@@ -1,63 +1,214 @@
 package service
 
 import (
+	"awsome-shop/internal/config"
+	"awsome-shop/internal/events"
 	"awsome-shop/internal/repository"
+	"awsome-shop/internal/storage"
 
 	"gorm.io/gorm"
 )
 
 // Services holds all service instances
 type Services struct {
-	Auth       *AuthService
-	User       *UserService
-	Product    *ProductService
-	Points     *PointsService
-	Redemption *RedemptionService
+	Auth             *AuthService
+	User             *UserService
+	Product          *ProductService
+	Points           *PointsService
+	Redemption       *RedemptionService
+	Idempotency      *IdempotencyService
+	Reconciliation   *ReconciliationService
+	WelcomeBonus     *WelcomeBonusService
+	Campaign         *CampaignService
+	Ledger           *LedgerService
+	Statement        *StatementService
+	ManagerAllowance *ManagerAllowanceService
+	Anomaly          *AnomalyService
+	Segment          *SegmentService
+	ReasonCode       *ReasonCodeService
+	Category         *CategoryService
+	ProductImage     *ProductImageService
+	PriceSchedule    *PriceScheduleService
+	Inventory        *InventoryService
+	Notification     *NotificationService
+	Events           *events.Bus
 }
 
 // NewServices creates and initializes all services
-func NewServices(repos *repository.Repositories, db *gorm.DB, jwtSecret string, jwtExpirationHours int) *Services {
+func NewServices(repos *repository.Repositories, db *gorm.DB, cfg *config.Config) *Services {
+	// Domain events are delivered in-process to the services that subscribe to them
+	eventBus := events.NewBus()
+
+	// Welcome bonus policy is shared by login and employee creation
+	welcomeBonusService := NewWelcomeBonusService(
+		repos.WelcomeBonusPolicy,
+		repos.UserWallet,
+	)
+
 	// Create AuthService first (needed by UserService)
 	authService := NewAuthService(
 		repos.User,
 		repos.PointsTransaction,
+		welcomeBonusService,
 		db,
-		jwtSecret,
-		jwtExpirationHours,
+		cfg.JWT.Secret,
+		cfg.JWT.ExpirationHours,
 	)
 
 	// Create other services
 	userService := NewUserService(
 		repos.User,
 		repos.PointsTransaction,
+		repos.UserWallet,
 		authService,
+		welcomeBonusService,
 		db,
 	)
 
+	categoryService := NewCategoryService(
+		repos.Category,
+		repos.Tag,
+	)
+
 	productService := NewProductService(
 		repos.Product,
+		repos.PriceHistory,
+		repos.Tag,
+		repos.ProductVariant,
+		repos.InventoryMovement,
+		repos.User,
+		repos.ProductSearch,
+		categoryService,
+		eventBus,
+		db,
+	)
+
+	productImageService := NewProductImageService(
+		repos.Product,
+		repos.ProductImage,
+		storage.New(cfg.Storage),
+		cfg.Storage,
 		db,
 	)
 
 	pointsService := NewPointsService(
 		repos.User,
 		repos.PointsTransaction,
+		repos.UserWallet,
+		repos.GrantApproval,
+		repos.ReasonCode,
 		db,
 	)
 
+	campaignService := NewCampaignService(
+		repos.Campaign,
+		repos.Product,
+		repos.Category,
+		categoryService,
+	)
+
 	redemptionService := NewRedemptionService(
 		repos.User,
 		repos.Product,
+		repos.ProductVariant,
 		repos.RedemptionOrder,
 		repos.PointsTransaction,
+		repos.UserWallet,
+		repos.InventoryMovement,
+		campaignService,
+		eventBus,
+		db,
+	)
+
+	idempotencyService := NewIdempotencyService(
+		repos.IdempotencyKey,
+		cfg.Idempotency.TTLHours,
+		cfg.Idempotency.MaxBodyMB,
+	)
+
+	reconciliationService := NewReconciliationService(
+		repos.User,
+		repos.PointsTransaction,
+		repos.UserWallet,
+		db,
+	)
+
+	ledgerService := NewLedgerService(
+		repos.User,
+		repos.PointsTransaction,
+	)
+
+	managerAllowanceService := NewManagerAllowanceService(
+		repos.ManagerAllowance,
+		repos.User,
+		pointsService,
+		db,
+	)
+
+	reasonCodeService := NewReasonCodeService(
+		repos.ReasonCode,
+	)
+
+	segmentService := NewSegmentService(
+		repos.UserSegment,
+		repos.User,
+		pointsService,
+		db,
+	)
+
+	anomalyService := NewAnomalyService(
+		repos.PointsTransaction,
+		cfg.Anomaly,
+	)
+
+	statementService := NewStatementService(
+		repos.User,
+		repos.PointsTransaction,
+		repos.PointsStatement,
+	)
+
+	inventoryService := NewInventoryService(
+		repos.Product,
+		repos.ProductVariant,
+		repos.RedemptionOrder,
+		repos.InventoryMovement,
+		eventBus,
 		db,
 	)
 
+	notificationService := NewNotificationService(
+		repos.Notification,
+		repos.User,
+		eventBus,
+	)
+
+	priceScheduleService := NewPriceScheduleService(
+		repos.PriceSchedule,
+		repos.Product,
+		productService,
+	)
+
 	return &Services{
-		Auth:       authService,
-		User:       userService,
-		Product:    productService,
-		Points:     pointsService,
-		Redemption: redemptionService,
+		Auth:             authService,
+		User:             userService,
+		Product:          productService,
+		Points:           pointsService,
+		Redemption:       redemptionService,
+		Idempotency:      idempotencyService,
+		Reconciliation:   reconciliationService,
+		WelcomeBonus:     welcomeBonusService,
+		Campaign:         campaignService,
+		Ledger:           ledgerService,
+		Statement:        statementService,
+		ManagerAllowance: managerAllowanceService,
+		Anomaly:          anomalyService,
+		Segment:          segmentService,
+		ReasonCode:       reasonCodeService,
+		Category:         categoryService,
+		ProductImage:     productImageService,
+		PriceSchedule:    priceScheduleService,
+		Inventory:        inventoryService,
+		Notification:     notificationService,
+		Events:           eventBus,
 	}
 }
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+)
+
+// statementPeriodLayout is the format of a statement period, e.g. "2024-01"
+const statementPeriodLayout = "2006-01"
+
+// StatementService generates monthly points statements from the points_transactions ledger
+type StatementService struct {
+	userRepo              *repository.UserRepository
+	pointsTransactionRepo *repository.PointsTransactionRepository
+	statementRepo         *repository.PointsStatementRepository
+}
+
+// NewStatementService creates a new StatementService instance
+func NewStatementService(
+	userRepo *repository.UserRepository,
+	pointsTransactionRepo *repository.PointsTransactionRepository,
+	statementRepo *repository.PointsStatementRepository,
+) *StatementService {
+	return &StatementService{
+		userRepo:              userRepo,
+		pointsTransactionRepo: pointsTransactionRepo,
+		statementRepo:         statementRepo,
+	}
+}
+
+// StatementView is a statement together with the ledger entries it covers
+// Provisional is set for the current month, which is computed on request and not stored.
+type StatementView struct {
+	Statement    *models.PointsStatement    `json:"statement"`
+	Provisional  bool                       `json:"provisional"`
+	Transactions []models.PointsTransaction `json:"transactions"`
+}
+
+// StatementGenerationResult is the outcome of a bulk statement generation run
+type StatementGenerationResult struct {
+	Period    string `json:"period"`
+	Generated int    `json:"generated"`
+	Skipped   int    `json:"skipped"`
+}
+
+// parseStatementPeriod parses a YYYY-MM period into its [start, end) range in local time
+func parseStatementPeriod(period string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(statementPeriodLayout, period, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.New("invalid period: expected YYYY-MM")
+	}
+	return start, start.AddDate(0, 1, 0), nil
+}
+
+// PreviousPeriod returns the most recently closed statement period
+func PreviousPeriod(now time.Time) string {
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return currentMonth.AddDate(0, -1, 0).Format(statementPeriodLayout)
+}
+
+// buildStatement computes a user's statement for [start, end) from the ledger
+func (s *StatementService) buildStatement(userID uint, period string, start, end time.Time) (*models.PointsStatement, []models.PointsTransaction, error) {
+	openingBalance, err := s.pointsTransactionRepo.SumAmountByUserIDBefore(userID, start)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transactions, err := s.pointsTransactionRepo.GetByUserIDInRange(userID, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statement := &models.PointsStatement{
+		UserID:           userID,
+		Period:           period,
+		PeriodStart:      start,
+		PeriodEnd:        end,
+		OpeningBalance:   openingBalance,
+		TransactionCount: len(transactions),
+	}
+
+	for _, transaction := range transactions {
+		if transaction.Amount > 0 {
+			statement.TotalCredits += transaction.Amount
+		} else {
+			statement.TotalDebits += -transaction.Amount
+		}
+	}
+	statement.ClosingBalance = statement.OpeningBalance + statement.TotalCredits - statement.TotalDebits
+
+	lastTransaction, err := s.pointsTransactionRepo.GetLastByUserIDBefore(userID, end)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lastTransaction != nil {
+		statement.LedgerHash = lastTransaction.Hash
+	}
+
+	return statement, transactions, nil
+}
+
+// generateClosedStatement builds and stores a statement for a closed period
+// If another request stored it first, the stored statement is returned instead.
+func (s *StatementService) generateClosedStatement(userID uint, period string, start, end time.Time) (*models.PointsStatement, []models.PointsTransaction, error) {
+	statement, transactions, err := s.buildStatement(userID, period, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.statementRepo.Create(statement); err != nil {
+		existing, getErr := s.statementRepo.GetByUserAndPeriod(userID, period)
+		if getErr != nil {
+			return nil, nil, err
+		}
+		return existing, transactions, nil
+	}
+
+	return statement, transactions, nil
+}
+
+// GetStatement retrieves a user's statement for a period (YYYY-MM)
+// Closed periods are generated and stored on first request; the current month is provisional.
+func (s *StatementService) GetStatement(userID uint, period string) (*StatementView, error) {
+	start, end, err := parseStatementPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if start.After(now) {
+		return nil, errors.New("statement period has not started yet")
+	}
+
+	// Current month: compute without storing
+	if end.After(now) {
+		statement, transactions, err := s.buildStatement(userID, period, start, end)
+		if err != nil {
+			return nil, err
+		}
+		return &StatementView{Statement: statement, Provisional: true, Transactions: transactions}, nil
+	}
+
+	statement, err := s.statementRepo.GetByUserAndPeriod(userID, period)
+	if err != nil {
+		if !errors.Is(err, repository.ErrPointsStatementNotFound) {
+			return nil, err
+		}
+
+		statement, transactions, err := s.generateClosedStatement(userID, period, start, end)
+		if err != nil {
+			return nil, err
+		}
+		return &StatementView{Statement: statement, Transactions: transactions}, nil
+	}
+
+	transactions, err := s.pointsTransactionRepo.GetByUserIDInRange(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StatementView{Statement: statement, Transactions: transactions}, nil
+}
+
+// ListStatements lists a user's stored statements (newest first)
+func (s *StatementService) ListStatements(userID uint) ([]models.PointsStatement, error) {
+	return s.statementRepo.ListByUserID(userID)
+}
+
+// GenerateStatements stores statements for every user who does not have one yet for a closed period
+// Users created after the period ended are skipped.
+func (s *StatementService) GenerateStatements(period string) (*StatementGenerationResult, error) {
+	start, end, err := parseStatementPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+
+	if end.After(time.Now()) {
+		return nil, errors.New("statements can only be generated once the period has closed")
+	}
+
+	existingUserIDs, err := s.statementRepo.GetUserIDsWithPeriod(period)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uint]bool, len(existingUserIDs))
+	for _, userID := range existingUserIDs {
+		existing[userID] = true
+	}
+
+	users, err := s.userRepo.List(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StatementGenerationResult{Period: period}
+	for _, user := range users {
+		if existing[user.ID] || !user.CreatedAt.Before(end) {
+			result.Skipped++
+			continue
+		}
+
+		if _, _, err := s.generateClosedStatement(user.ID, period, start, end); err != nil {
+			return nil, fmt.Errorf("failed to generate statement for user %d: %w", user.ID, err)
+		}
+		result.Generated++
+	}
+
+	return result, nil
+}
+
+// RunMonthlyGeneration generates statements for the previous month on a fixed interval
+// Runs are cheap once every user has a statement, so a short interval simply picks up
+// the new period soon after month end. It blocks, so it should be started in its own goroutine.
+func (s *StatementService) RunMonthlyGeneration(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		result, err := s.GenerateStatements(PreviousPeriod(time.Now()))
+		if err != nil {
+			log.Printf("Failed to generate points statements: %v", err)
+			continue
+		}
+		if result.Generated > 0 {
+			log.Printf("Generated %d points statements for %s", result.Generated, result.Period)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+
+	// Register the GIF decoder; JPEG and PNG are registered by the imports above
+	_ "image/gif"
+)
+
+// Image formats accepted for upload, keyed by sniffed content type
+var imageFormats = map[string]struct {
+	format    string
+	extension string
+}{
+	"image/jpeg": {"jpeg", ".jpg"},
+	"image/png":  {"png", ".png"},
+	"image/gif":  {"gif", ".gif"},
+}
+
+// decodeImage checks an image's dimensions before decoding it in full
+// Checking the header first keeps a small file that claims huge dimensions from exhausting memory.
+func decodeImage(data []byte, maxPixels int) (image.Image, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("file is not a valid image")
+	}
+
+	if config.Width > maxPixels || config.Height > maxPixels {
+		return nil, fmt.Errorf("image cannot be larger than %dx%d pixels", maxPixels, maxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.New("file is not a valid image")
+	}
+
+	return img, nil
+}
+
+// resizeToFit scales an image down to fit within a size×size square
+// Each thumbnail pixel averages the source pixels it covers. Images that already fit are returned as-is.
+func resizeToFit(src image.Image, size int) image.Image {
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return src
+	}
+
+	thumbWidth, thumbHeight := size, size
+	if width >= height {
+		thumbHeight = max(1, height*size/width)
+	} else {
+		thumbWidth = max(1, width*size/height)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, thumbWidth, thumbHeight))
+	for y := 0; y < thumbHeight; y++ {
+		srcY0 := bounds.Min.Y + y*height/thumbHeight
+		srcY1 := max(srcY0+1, bounds.Min.Y+(y+1)*height/thumbHeight)
+
+		for x := 0; x < thumbWidth; x++ {
+			srcX0 := bounds.Min.X + x*width/thumbWidth
+			srcX1 := max(srcX0+1, bounds.Min.X+(x+1)*width/thumbWidth)
+
+			var r, g, b, a, n uint64
+			for sy := srcY0; sy < srcY1; sy++ {
+				for sx := srcX0; sx < srcX1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n),
+				G: uint16(g / n),
+				B: uint16(b / n),
+				A: uint16(a / n),
+			})
+		}
+	}
+
+	return dst
+}
+
+// encodeThumbnail encodes a thumbnail, keeping PNG and GIF sources lossless so transparency survives
+// It returns the encoded bytes with their content type and file extension.
+func encodeThumbnail(img image.Image, sourceFormat string) ([]byte, string, string, error) {
+	var buf bytes.Buffer
+
+	if sourceFormat == "jpeg" {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 85}); err != nil {
+			return nil, "", "", err
+		}
+		return buf.Bytes(), "image/jpeg", ".jpg", nil
+	}
+
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, "", "", err
+	}
+	return buf.Bytes(), "image/png", ".png", nil
+}
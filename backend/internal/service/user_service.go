@@ -6,42 +6,53 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 // UserService handles user management operations
 type UserService struct {
-	userRepo            *repository.UserRepository
+	userRepo              *repository.UserRepository
 	pointsTransactionRepo *repository.PointsTransactionRepository
-	authService         *AuthService
-	db                  *gorm.DB
+	walletRepo            *repository.UserWalletRepository
+	authService           *AuthService
+	welcomeBonusService   *WelcomeBonusService
+	db                    *gorm.DB
 }
 
 // NewUserService creates a new UserService instance
 func NewUserService(
 	userRepo *repository.UserRepository,
 	pointsTransactionRepo *repository.PointsTransactionRepository,
+	walletRepo *repository.UserWalletRepository,
 	authService *AuthService,
+	welcomeBonusService *WelcomeBonusService,
 	db *gorm.DB,
 ) *UserService {
 	return &UserService{
-		userRepo:            userRepo,
+		userRepo:              userRepo,
 		pointsTransactionRepo: pointsTransactionRepo,
-		authService:         authService,
-		db:                  db,
+		walletRepo:            walletRepo,
+		authService:           authService,
+		welcomeBonusService:   welcomeBonusService,
+		db:                    db,
 	}
 }
 
 // CreateEmployeeRequest represents a request to create an employee
 type CreateEmployeeRequest struct {
-	FullName string `json:"full_name" binding:"required"`
-	Email    string `json:"email" binding:"required,email"`
-	Phone    string `json:"phone" binding:"required"`
+	FullName   string     `json:"full_name" binding:"required"`
+	Email      string     `json:"email" binding:"required,email"`
+	Phone      string     `json:"phone" binding:"required"`
+	Department string     `json:"department"`
+	Location   string     `json:"location"`
+	JoinDate   *time.Time `json:"join_date"`
 }
 
 // CreateEmployee creates a new employee account
-// Initial password is set to the last 6 digits of the phone number
+// Initial password is set to the last 6 digits of the phone number.
+// The welcome bonus is granted in the same transaction when the policy grants it at creation.
 func (s *UserService) CreateEmployee(req *CreateEmployeeRequest) (*models.User, error) {
 	// Validate phone number format
 	if len(req.Phone) < 6 {
@@ -77,9 +88,38 @@ func (s *UserService) CreateEmployee(req *CreateEmployeeRequest) (*models.User,
 		IsFirstLogin:      true,
 		IsActive:          true,
 		PreferredLanguage: "zh",
+		Department:        req.Department,
+		Location:          req.Location,
+		JoinDate:          req.JoinDate,
 	}
 
-	err = s.userRepo.Create(user)
+	// Start transaction
+	tx := s.db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+		}
+	}()
+
+	err = tx.Create(user).Error
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Grant the welcome bonus if the policy grants it at creation
+	_, err = s.welcomeBonusService.GrantIfDue(tx, user, WelcomeBonusAtCreation)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// Commit transaction
+	err = tx.Commit().Error
 	if err != nil {
 		return nil, err
 	}
@@ -152,22 +192,56 @@ func (s *UserService) SetEmployeeDeparture(userID uint, operatorID uint) error {
 		return err
 	}
 
-	// If user has points, create a deduction transaction to invalidate them
-	if user.PointsBalance > 0 {
-		transaction := &models.PointsTransaction{
-			UserID:          userID,
-			TransactionType: "deduct",
-			Amount:          -user.PointsBalance,
-			BalanceAfter:    0,
-			Reason:          fmt.Sprintf("员工离职，积分失效 / Employee departure, points invalidated"),
-			OperatorID:      &operatorID,
-			RelatedOrderID:  nil,
-		}
+	// Lock the user so no points are credited while the wallets are emptied
+	lockedUsers, err := s.userRepo.GetByIDsWithLock(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	if len(lockedUsers) == 0 {
+		tx.Rollback()
+		return errors.New("user not found")
+	}
+	user = &lockedUsers[0]
 
-		err = tx.Create(transaction).Error
-		if err != nil {
-			tx.Rollback()
-			return err
+	walletBalances, err := s.walletRepo.GetBalances(tx, []uint{userID})
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// If user has points, create a deduction transaction per wallet to invalidate them
+	if user.PointsBalance > 0 {
+		runningBalance := user.PointsBalance
+		for _, wallet := range models.WalletTypes {
+			balance := walletBalances[userID][wallet]
+			if balance <= 0 {
+				continue
+			}
+			runningBalance -= balance
+
+			err = s.walletRepo.AdjustBalance(tx, userID, wallet, -balance)
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
+
+			transaction := &models.PointsTransaction{
+				UserID:          userID,
+				TransactionType: "deduct",
+				Wallet:          wallet,
+				Amount:          -balance,
+				BalanceAfter:    runningBalance,
+				Reason:          fmt.Sprintf("员工离职，积分失效 / Employee departure, points invalidated"),
+				OperatorID:      &operatorID,
+				RelatedOrderID:  nil,
+			}
+
+			err = tx.Create(transaction).Error
+			if err != nil {
+				tx.Rollback()
+				return err
+			}
 		}
 
 		// Set points balance to 0
@@ -230,6 +304,41 @@ func (s *UserService) ListUsers(isActive *bool) ([]models.User, error) {
 	return users, nil
 }
 
+// SetManager sets or clears (nil managerID) a user's manager
+// A user cannot manage themselves, directly or through a chain of managers.
+func (s *UserService) SetManager(userID uint, managerID *uint) error {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return err
+	}
+
+	if managerID != nil {
+		manager, err := s.userRepo.GetByID(*managerID)
+		if err != nil {
+			return errors.New("manager not found")
+		}
+
+		if !manager.IsActive {
+			return errors.New("manager is inactive")
+		}
+
+		// Walk up the proposed management chain to reject cycles
+		for current := manager; ; {
+			if current.ID == userID {
+				return errors.New("a user cannot be their own manager")
+			}
+			if current.ManagerID == nil {
+				break
+			}
+			current, err = s.userRepo.GetByID(*current.ManagerID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.userRepo.UpdateManager(userID, managerID)
+}
+
 // IsAdmin checks if a user is an administrator
 func (s *UserService) IsAdmin(userID uint) (bool, error) {
 	user, err := s.userRepo.GetByID(userID)
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// walletSpendOrder is the order redemptions draw from a product's accepted wallets
+// Company-funded benefit points are spent before peer recognition points.
+var walletSpendOrder = []string{models.WalletBenefit, models.WalletRecognition}
+
+// WalletBalance is a user's balance in one wallet
+type WalletBalance struct {
+	Wallet  string `json:"wallet"`
+	Balance int    `json:"balance"`
+}
+
+// WalletSpend is the part of a payment drawn from one wallet
+type WalletSpend struct {
+	Wallet string `json:"wallet"`
+	Amount int    `json:"amount"`
+}
+
+// normalizeWallet validates a wallet type; an empty value selects the benefit wallet
+func normalizeWallet(wallet string) (string, error) {
+	if wallet == "" {
+		return models.WalletBenefit, nil
+	}
+
+	for _, walletType := range models.WalletTypes {
+		if wallet == walletType {
+			return wallet, nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid wallet: %s", wallet)
+}
+
+// normalizeAcceptedWallets validates a product's accepted wallets and joins them for storage
+// An empty list accepts every wallet.
+func normalizeAcceptedWallets(wallets []string) (string, error) {
+	if len(wallets) == 0 {
+		return strings.Join(models.WalletTypes, ","), nil
+	}
+
+	accepted := make(map[string]bool, len(wallets))
+	for _, wallet := range wallets {
+		if wallet == "" {
+			return "", errors.New("accepted wallets cannot contain an empty value")
+		}
+		wallet, err := normalizeWallet(wallet)
+		if err != nil {
+			return "", err
+		}
+		accepted[wallet] = true
+	}
+
+	// Store in a stable order
+	var normalized []string
+	for _, walletType := range models.WalletTypes {
+		if accepted[walletType] {
+			normalized = append(normalized, walletType)
+		}
+	}
+
+	return strings.Join(normalized, ","), nil
+}
+
+// planWalletSpend splits a price across the accepted wallets in spend order
+func planWalletSpend(acceptedWallets string, balances map[string]int, price int) ([]WalletSpend, error) {
+	accepted := make(map[string]bool)
+	for _, wallet := range strings.Split(acceptedWallets, ",") {
+		accepted[strings.TrimSpace(wallet)] = true
+	}
+
+	var spends []WalletSpend
+	remaining := price
+	for _, wallet := range walletSpendOrder {
+		if remaining == 0 {
+			break
+		}
+		if !accepted[wallet] || balances[wallet] <= 0 {
+			continue
+		}
+
+		amount := balances[wallet]
+		if amount > remaining {
+			amount = remaining
+		}
+		spends = append(spends, WalletSpend{Wallet: wallet, Amount: amount})
+		remaining -= amount
+	}
+
+	if remaining > 0 {
+		return nil, errors.New("insufficient points")
+	}
+
+	return spends, nil
+}
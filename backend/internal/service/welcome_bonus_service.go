@@ -0,0 +1,229 @@
+package service
+
+import (
+	"awsome-shop/internal/models"
+	"awsome-shop/internal/repository"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Welcome bonus grant timings
+const (
+	WelcomeBonusAtCreation   = "creation"
+	WelcomeBonusAtFirstLogin = "first_login"
+)
+
+// defaultWelcomeBonusAmount is used until an admin saves a policy
+const defaultWelcomeBonusAmount = 1000
+
+// WelcomeBonusService decides and grants the welcome bonus for new employees
+type WelcomeBonusService struct {
+	policyRepo *repository.WelcomeBonusPolicyRepository
+	walletRepo *repository.UserWalletRepository
+}
+
+// NewWelcomeBonusService creates a new WelcomeBonusService instance
+func NewWelcomeBonusService(
+	policyRepo *repository.WelcomeBonusPolicyRepository,
+	walletRepo *repository.UserWalletRepository,
+) *WelcomeBonusService {
+	return &WelcomeBonusService{
+		policyRepo: policyRepo,
+		walletRepo: walletRepo,
+	}
+}
+
+// GetPolicy returns the current welcome bonus policy
+// Until a policy is saved, the default grants 1000 points at first login
+func (s *WelcomeBonusService) GetPolicy() (*models.WelcomeBonusPolicy, error) {
+	policy, err := s.policyRepo.Get()
+	if err == nil {
+		return policy, nil
+	}
+
+	if !errors.Is(err, repository.ErrWelcomeBonusPolicyNotFound) {
+		return nil, err
+	}
+
+	return &models.WelcomeBonusPolicy{
+		Enabled:       true,
+		GrantAt:       WelcomeBonusAtFirstLogin,
+		DefaultAmount: defaultWelcomeBonusAmount,
+		Rules:         []models.WelcomeBonusRule{},
+	}, nil
+}
+
+// WelcomeBonusRuleRequest represents a role/department override in a policy update
+type WelcomeBonusRuleRequest struct {
+	Role       string `json:"role"`
+	Department string `json:"department"`
+	Amount     int    `json:"amount"`
+}
+
+// UpdateWelcomeBonusPolicyRequest represents a request to update the welcome bonus policy
+type UpdateWelcomeBonusPolicyRequest struct {
+	Enabled            bool                      `json:"enabled"`
+	GrantAt            string                    `json:"grant_at"`
+	DefaultAmount      int                       `json:"default_amount"`
+	ProRateByJoinMonth bool                      `json:"pro_rate_by_join_month"`
+	Rules              []WelcomeBonusRuleRequest `json:"rules"`
+}
+
+// UpdatePolicy replaces the welcome bonus policy and its rules
+func (s *WelcomeBonusService) UpdatePolicy(req *UpdateWelcomeBonusPolicyRequest, operatorID uint) (*models.WelcomeBonusPolicy, error) {
+	if req.GrantAt != WelcomeBonusAtCreation && req.GrantAt != WelcomeBonusAtFirstLogin {
+		return nil, errors.New("invalid grant_at: must be 'creation' or 'first_login'")
+	}
+
+	if req.DefaultAmount < 0 {
+		return nil, errors.New("default amount cannot be negative")
+	}
+
+	rules := make([]models.WelcomeBonusRule, 0, len(req.Rules))
+	for i, rule := range req.Rules {
+		if rule.Role == "" && rule.Department == "" {
+			return nil, fmt.Errorf("rule %d: role or department is required", i+1)
+		}
+		if rule.Role != "" && rule.Role != "employee" && rule.Role != "admin" {
+			return nil, fmt.Errorf("rule %d: invalid role: must be 'employee' or 'admin'", i+1)
+		}
+		if rule.Amount < 0 {
+			return nil, fmt.Errorf("rule %d: amount cannot be negative", i+1)
+		}
+
+		rules = append(rules, models.WelcomeBonusRule{
+			Role:       rule.Role,
+			Department: rule.Department,
+			Amount:     rule.Amount,
+		})
+	}
+
+	policy, err := s.GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	policy.Enabled = req.Enabled
+	policy.GrantAt = req.GrantAt
+	policy.DefaultAmount = req.DefaultAmount
+	policy.ProRateByJoinMonth = req.ProRateByJoinMonth
+	policy.Rules = rules
+	policy.UpdatedBy = &operatorID
+
+	err = s.policyRepo.Save(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// CalculateBonus returns the welcome bonus for a user under a policy
+// The most specific rule wins: role and department, then department, then role, then the default.
+// With pro-rating, users who joined this year receive the share of the year remaining from their join month.
+func (s *WelcomeBonusService) CalculateBonus(policy *models.WelcomeBonusPolicy, user *models.User, now time.Time) int {
+	amount := policy.DefaultAmount
+	bestScore := 0
+
+	for _, rule := range policy.Rules {
+		if rule.Role != "" && rule.Role != user.Role {
+			continue
+		}
+		if rule.Department != "" && rule.Department != user.Department {
+			continue
+		}
+
+		score := 0
+		if rule.Department != "" {
+			score += 2
+		}
+		if rule.Role != "" {
+			score++
+		}
+
+		if score > bestScore {
+			bestScore = score
+			amount = rule.Amount
+		}
+	}
+
+	if policy.ProRateByJoinMonth && user.JoinDate != nil && user.JoinDate.Year() == now.Year() {
+		remainingMonths := 12 - int(user.JoinDate.Month()) + 1
+		amount = amount * remainingMonths / 12
+	}
+
+	return amount
+}
+
+// GrantIfDue grants the welcome bonus inside tx when the policy grants at the given timing
+// The user must already be locked or freshly created in tx. A user receives the bonus at most once,
+// and the user's balance and WelcomeBonusGrantedAt are updated in place. Returns the amount granted.
+func (s *WelcomeBonusService) GrantIfDue(tx *gorm.DB, user *models.User, timing string) (int, error) {
+	if user.WelcomeBonusGrantedAt != nil {
+		return 0, nil
+	}
+
+	policy, err := s.GetPolicy()
+	if err != nil {
+		return 0, err
+	}
+
+	if !policy.Enabled || policy.GrantAt != timing {
+		return 0, nil
+	}
+
+	now := time.Now()
+	amount := s.CalculateBonus(policy, user, now)
+	if amount <= 0 {
+		return 0, nil
+	}
+
+	newBalance := user.PointsBalance + amount
+
+	// Update user points balance and mark the bonus as granted
+	err = tx.Model(&models.User{}).
+		Where("id = ?", user.ID).
+		Updates(map[string]interface{}{
+			"points_balance":           newBalance,
+			"welcome_bonus_granted_at": now,
+		}).Error
+	if err != nil {
+		return 0, err
+	}
+
+	// The welcome bonus is a benefit allowance
+	err = s.walletRepo.AdjustBalance(tx, user.ID, models.WalletBenefit, amount)
+	if err != nil {
+		return 0, err
+	}
+
+	reason := "入职欢迎奖励 / Welcome bonus"
+	if timing == WelcomeBonusAtFirstLogin {
+		reason = "首次登录奖励 / First login bonus"
+	}
+
+	// Create points transaction record
+	transaction := &models.PointsTransaction{
+		UserID:          user.ID,
+		TransactionType: "grant",
+		Wallet:          models.WalletBenefit,
+		Amount:          amount,
+		BalanceAfter:    newBalance,
+		Reason:          reason,
+		OperatorID:      nil, // System operation
+		RelatedOrderID:  nil,
+	}
+
+	err = tx.Create(transaction).Error
+	if err != nil {
+		return 0, err
+	}
+
+	user.PointsBalance = newBalance
+	user.WelcomeBonusGrantedAt = &now
+
+	return amount, nil
+}
@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"awsome-shop/internal/config"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned when a key does not exist in the storage backend
+var ErrNotFound = errors.New("object not found")
+
+// Object is a stored file opened for reading
+type Object struct {
+	Content     io.ReadSeekCloser
+	ContentType string
+	Size        int64
+	ModTime     time.Time
+}
+
+// Storage stores uploaded files under slash-separated keys such as "products/12/ab12.jpg"
+// Implementations must be safe for concurrent use. The local filesystem is the only backend
+// today; an S3-compatible backend only has to implement this interface.
+type Storage interface {
+	// Put writes content under key, replacing any existing object
+	Put(key string, content io.Reader, contentType string) error
+	// Get opens the object stored under key, returning ErrNotFound if there is none
+	Get(key string) (*Object, error)
+	// Delete removes the object stored under key; deleting a missing key is not an error
+	Delete(key string) error
+}
+
+// New creates the storage backend selected in the configuration
+// config.Load rejects unsupported drivers, so this always returns a usable backend.
+func New(cfg config.StorageConfig) Storage {
+	return NewLocalStorage(cfg.LocalDir)
+}
@@ -0,0 +1,17 @@
+-- Create idempotency_keys table
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    `key` VARCHAR(255) NOT NULL COMMENT '客户端幂等键',
+    method VARCHAR(10) NOT NULL COMMENT '请求方法',
+    path VARCHAR(500) NOT NULL COMMENT '请求路径',
+    request_hash CHAR(64) NOT NULL COMMENT '请求内容哈希',
+    response_status INT DEFAULT 0 COMMENT '响应状态码（0表示处理中）',
+    response_body MEDIUMTEXT COMMENT '响应内容',
+    expires_at TIMESTAMP NOT NULL COMMENT '过期时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_user_key (user_id, `key`),
+    INDEX idx_expires_at (expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='幂等键表';
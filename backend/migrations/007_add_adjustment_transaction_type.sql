@@ -0,0 +1,3 @@
+-- Add adjustment type for ledger reconciliation corrections
+ALTER TABLE points_transactions
+    MODIFY COLUMN transaction_type ENUM('grant', 'deduct', 'redemption', 'adjustment') NOT NULL COMMENT '交易类型';
@@ -0,0 +1,6 @@
+-- Add reversal transactions linked to the original entry
+ALTER TABLE points_transactions
+    MODIFY COLUMN transaction_type ENUM('grant', 'deduct', 'redemption', 'adjustment', 'reversal') NOT NULL COMMENT '交易类型',
+    ADD COLUMN reversal_of_id BIGINT NULL COMMENT '被冲正的原交易ID' AFTER related_order_id,
+    ADD UNIQUE INDEX idx_reversal_of (reversal_of_id),
+    ADD FOREIGN KEY (reversal_of_id) REFERENCES points_transactions(id);
@@ -0,0 +1,29 @@
+-- Add employee attributes used by the welcome bonus policy
+ALTER TABLE users
+    ADD COLUMN department VARCHAR(100) COMMENT '部门' AFTER preferred_language,
+    ADD COLUMN join_date DATE COMMENT '入职日期' AFTER department,
+    ADD COLUMN welcome_bonus_granted_at TIMESTAMP NULL COMMENT '欢迎奖励发放时间' AFTER join_date;
+
+-- Create welcome_bonus_policies table
+CREATE TABLE IF NOT EXISTS welcome_bonus_policies (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    enabled BOOLEAN NOT NULL COMMENT '是否启用',
+    grant_at ENUM('creation', 'first_login') NOT NULL COMMENT '发放时机（创建账户/首次登录）',
+    default_amount INT NOT NULL COMMENT '默认奖励积分',
+    pro_rate_by_join_month BOOLEAN NOT NULL COMMENT '是否按入职月份折算',
+    updated_by BIGINT COMMENT '最后修改人ID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (updated_by) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='欢迎奖励策略表';
+
+-- Create welcome_bonus_rules table
+CREATE TABLE IF NOT EXISTS welcome_bonus_rules (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    policy_id BIGINT NOT NULL COMMENT '策略ID',
+    role VARCHAR(20) COMMENT '角色（空表示任意）',
+    department VARCHAR(100) COMMENT '部门（空表示任意）',
+    amount INT NOT NULL COMMENT '奖励积分',
+    INDEX idx_policy (policy_id),
+    FOREIGN KEY (policy_id) REFERENCES welcome_bonus_policies(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='欢迎奖励规则表';
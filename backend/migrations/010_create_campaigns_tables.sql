@@ -0,0 +1,39 @@
+-- Create campaigns table
+CREATE TABLE IF NOT EXISTS campaigns (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(200) NOT NULL COMMENT '活动名称',
+    description VARCHAR(1000) COMMENT '活动描述',
+    starts_at TIMESTAMP NOT NULL COMMENT '开始时间',
+    ends_at TIMESTAMP NOT NULL COMMENT '结束时间',
+    rule_type ENUM('discount', 'bonus_points') NOT NULL COMMENT '规则类型（折扣/奖励积分）',
+    discount_percent INT DEFAULT 0 COMMENT '折扣百分比',
+    bonus_points INT DEFAULT 0 COMMENT '每次兑换奖励积分',
+    applies_to_all BOOLEAN DEFAULT FALSE COMMENT '是否适用于全部商品',
+    status ENUM('active', 'inactive') DEFAULT 'active' COMMENT '活动状态',
+    created_by BIGINT COMMENT '创建人ID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_starts_at (starts_at),
+    INDEX idx_ends_at (ends_at),
+    FOREIGN KEY (created_by) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='促销活动表';
+
+-- Create campaign_products table
+CREATE TABLE IF NOT EXISTS campaign_products (
+    campaign_id BIGINT NOT NULL COMMENT '活动ID',
+    product_id BIGINT NOT NULL COMMENT '产品ID',
+    PRIMARY KEY (campaign_id, product_id),
+    FOREIGN KEY (campaign_id) REFERENCES campaigns(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='活动商品关联表';
+
+-- Record campaign pricing on redemption orders
+ALTER TABLE redemption_orders
+    ADD COLUMN original_points_cost INT NOT NULL DEFAULT 0 COMMENT '原价积分' AFTER points_balance_after,
+    ADD COLUMN campaign_id BIGINT COMMENT '适用活动ID' AFTER original_points_cost,
+    ADD COLUMN campaign_bonus_points INT NOT NULL DEFAULT 0 COMMENT '活动奖励积分' AFTER campaign_id,
+    ADD INDEX idx_campaign (campaign_id),
+    ADD FOREIGN KEY (campaign_id) REFERENCES campaigns(id);
+
+-- Orders placed before campaigns existed were charged the list price
+UPDATE redemption_orders SET original_points_cost = points_cost WHERE original_points_cost = 0;
@@ -0,0 +1,5 @@
+-- Add tamper-evident hash chain to points_transactions
+-- Existing rows are hashed once by the application on startup (see database.Migrate)
+ALTER TABLE points_transactions
+    ADD COLUMN prev_hash CHAR(64) NOT NULL DEFAULT '' COMMENT '同一用户上一条流水的哈希' AFTER reversal_of_id,
+    ADD COLUMN hash CHAR(64) NOT NULL DEFAULT '' COMMENT '本条流水内容及上一条哈希的SHA-256' AFTER prev_hash;
@@ -0,0 +1,17 @@
+-- Create points_statements table
+CREATE TABLE IF NOT EXISTS points_statements (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    period CHAR(7) NOT NULL COMMENT '对账期间（YYYY-MM）',
+    period_start TIMESTAMP NOT NULL COMMENT '期间开始时间',
+    period_end TIMESTAMP NOT NULL COMMENT '期间结束时间（不含）',
+    opening_balance INT NOT NULL COMMENT '期初余额',
+    total_credits INT NOT NULL COMMENT '本期收入',
+    total_debits INT NOT NULL COMMENT '本期支出',
+    closing_balance INT NOT NULL COMMENT '期末余额',
+    transaction_count INT NOT NULL COMMENT '本期交易笔数',
+    ledger_hash CHAR(64) NOT NULL DEFAULT '' COMMENT '期末最后一条流水的哈希',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_user_period (user_id, period),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='积分月度对账单表';
@@ -0,0 +1,26 @@
+-- Create user_wallets table
+CREATE TABLE IF NOT EXISTS user_wallets (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL COMMENT '用户ID',
+    wallet ENUM('benefit', 'recognition') NOT NULL COMMENT '钱包类型（福利积分/认可积分）',
+    balance INT NOT NULL DEFAULT 0 COMMENT '钱包余额',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY idx_user_wallet (user_id, wallet),
+    FOREIGN KEY (user_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='用户积分钱包表';
+
+-- Tag points transactions with their wallet
+ALTER TABLE points_transactions
+    ADD COLUMN wallet ENUM('benefit', 'recognition') NOT NULL DEFAULT 'benefit' COMMENT '钱包类型' AFTER transaction_type,
+    ADD INDEX idx_wallet (wallet);
+
+-- Wallets each product can be redeemed with
+ALTER TABLE products
+    ADD COLUMN accepted_wallets SET('benefit', 'recognition') NOT NULL DEFAULT 'benefit,recognition' COMMENT '可用钱包' AFTER status;
+
+-- Existing balances were all benefit points
+INSERT INTO user_wallets (user_id, wallet, balance)
+SELECT id, 'benefit', points_balance FROM users
+WHERE points_balance <> 0
+AND NOT EXISTS (SELECT 1 FROM user_wallets WHERE user_wallets.user_id = users.id);
@@ -0,0 +1,30 @@
+-- Record each employee's manager
+ALTER TABLE users
+    ADD COLUMN manager_id BIGINT COMMENT '直属经理ID' AFTER department,
+    ADD INDEX idx_manager (manager_id),
+    ADD FOREIGN KEY (manager_id) REFERENCES users(id);
+
+-- Create manager_allowances table
+CREATE TABLE IF NOT EXISTS manager_allowances (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    manager_id BIGINT NOT NULL COMMENT '经理ID',
+    wallet ENUM('benefit', 'recognition') NOT NULL DEFAULT 'recognition' COMMENT '发放钱包',
+    amount INT NOT NULL COMMENT '额度',
+    used INT NOT NULL DEFAULT 0 COMMENT '已使用额度',
+    starts_at TIMESTAMP NOT NULL COMMENT '生效时间',
+    ends_at TIMESTAMP NOT NULL COMMENT '失效时间',
+    status ENUM('active', 'inactive') DEFAULT 'active' COMMENT '状态',
+    note VARCHAR(500) COMMENT '备注',
+    created_by BIGINT COMMENT '创建人ID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_manager (manager_id),
+    FOREIGN KEY (manager_id) REFERENCES users(id),
+    FOREIGN KEY (created_by) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='经理积分额度表';
+
+-- Tag grants made from a manager allowance
+ALTER TABLE points_transactions
+    ADD COLUMN allowance_id BIGINT COMMENT '来源经理额度ID' AFTER reversal_of_id,
+    ADD INDEX idx_allowance (allowance_id),
+    ADD FOREIGN KEY (allowance_id) REFERENCES manager_allowances(id);
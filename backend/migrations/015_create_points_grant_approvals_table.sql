@@ -0,0 +1,22 @@
+-- Create points_grant_approvals table
+CREATE TABLE IF NOT EXISTS points_grant_approvals (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL COMMENT '被发放用户ID',
+    wallet ENUM('benefit', 'recognition') NOT NULL DEFAULT 'benefit' COMMENT '发放钱包',
+    amount INT NOT NULL COMMENT '积分数量',
+    reason VARCHAR(500) COMMENT '发放原因',
+    requested_by BIGINT NOT NULL COMMENT '申请人ID',
+    status ENUM('pending', 'approved', 'rejected') DEFAULT 'pending' COMMENT '审批状态',
+    reviewed_by BIGINT COMMENT '审批人ID',
+    review_note VARCHAR(500) COMMENT '审批备注',
+    reviewed_at TIMESTAMP NULL COMMENT '审批时间',
+    transaction_id BIGINT COMMENT '审批通过后生成的积分流水ID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_user (user_id),
+    INDEX idx_status (status),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (requested_by) REFERENCES users(id),
+    FOREIGN KEY (reviewed_by) REFERENCES users(id),
+    FOREIGN KEY (transaction_id) REFERENCES points_transactions(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='积分发放审批表';
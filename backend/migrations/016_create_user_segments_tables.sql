@@ -0,0 +1,46 @@
+-- Record each employee's work location
+ALTER TABLE users
+    ADD COLUMN location VARCHAR(100) COMMENT '工作地点' AFTER department,
+    ADD INDEX idx_location (location);
+
+-- Create user_segments table
+CREATE TABLE IF NOT EXISTS user_segments (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(100) NOT NULL UNIQUE COMMENT '分组名称',
+    description VARCHAR(500) COMMENT '分组描述',
+    user_status ENUM('any', 'active', 'inactive') DEFAULT 'active' COMMENT '员工状态筛选',
+    department VARCHAR(100) COMMENT '部门筛选，空表示不限',
+    location VARCHAR(100) COMMENT '工作地点筛选，空表示不限',
+    role VARCHAR(20) COMMENT '角色筛选，空表示不限',
+    joined_from DATE COMMENT '入职日期起（含）',
+    joined_to DATE COMMENT '入职日期止（含）',
+    created_by BIGINT COMMENT '创建人ID',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (created_by) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='员工分组表';
+
+-- Create segment_grant_jobs table
+CREATE TABLE IF NOT EXISTS segment_grant_jobs (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    segment_id BIGINT NOT NULL COMMENT '分组ID',
+    wallet ENUM('benefit', 'recognition') NOT NULL DEFAULT 'benefit' COMMENT '发放钱包',
+    amount INT NOT NULL COMMENT '每人积分数量',
+    reason VARCHAR(500) COMMENT '发放原因',
+    status ENUM('pending', 'running', 'completed', 'failed') DEFAULT 'pending' COMMENT '任务状态',
+    total_users INT NOT NULL DEFAULT 0 COMMENT '创建时匹配人数',
+    granted_users INT NOT NULL DEFAULT 0 COMMENT '已发放人数',
+    skipped_users INT NOT NULL DEFAULT 0 COMMENT '已跳过人数',
+    total_granted INT NOT NULL DEFAULT 0 COMMENT '已发放积分总数',
+    last_user_id BIGINT NOT NULL DEFAULT 0 COMMENT '进度游标：已处理的最后一个用户ID',
+    error VARCHAR(500) COMMENT '失败原因',
+    operator_id BIGINT NOT NULL COMMENT '操作人ID',
+    started_at TIMESTAMP NULL COMMENT '开始时间',
+    finished_at TIMESTAMP NULL COMMENT '结束时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_segment (segment_id),
+    INDEX idx_status (status),
+    FOREIGN KEY (segment_id) REFERENCES user_segments(id),
+    FOREIGN KEY (operator_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='分组积分发放任务表';
@@ -0,0 +1,25 @@
+-- Create reason_codes table
+CREATE TABLE IF NOT EXISTS reason_codes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code VARCHAR(50) NOT NULL UNIQUE COMMENT '原因代码（大写）',
+    label_zh VARCHAR(100) NOT NULL COMMENT '中文名称',
+    label_en VARCHAR(100) NOT NULL COMMENT '英文名称',
+    note_required BOOLEAN DEFAULT FALSE COMMENT '是否必须填写备注',
+    status ENUM('active', 'inactive') DEFAULT 'active' COMMENT '状态',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='积分原因代码表';
+
+-- Reference reason codes from transactions and pending grants
+ALTER TABLE points_transactions
+    ADD COLUMN reason_code_id BIGINT COMMENT '原因代码ID' AFTER reason,
+    ADD INDEX idx_reason_code (reason_code_id),
+    ADD FOREIGN KEY (reason_code_id) REFERENCES reason_codes(id);
+
+ALTER TABLE points_grant_approvals
+    ADD COLUMN reason_code_id BIGINT COMMENT '原因代码ID' AFTER reason,
+    ADD FOREIGN KEY (reason_code_id) REFERENCES reason_codes(id);
+
+ALTER TABLE segment_grant_jobs
+    ADD COLUMN reason_code_id BIGINT COMMENT '原因代码ID' AFTER reason,
+    ADD FOREIGN KEY (reason_code_id) REFERENCES reason_codes(id);
@@ -0,0 +1,34 @@
+-- Create categories table
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    parent_id BIGINT COMMENT '上级分类ID，顶级分类为空',
+    name VARCHAR(100) NOT NULL COMMENT '分类名称',
+    sort_order INT DEFAULT 0 COMMENT '排序',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_parent (parent_id),
+    FOREIGN KEY (parent_id) REFERENCES categories(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品分类表';
+
+-- Create tags table
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(50) NOT NULL UNIQUE COMMENT '标签名称',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品标签表';
+
+-- Create product_tags join table
+CREATE TABLE IF NOT EXISTS product_tags (
+    product_id BIGINT NOT NULL COMMENT '商品ID',
+    tag_id BIGINT NOT NULL COMMENT '标签ID',
+    PRIMARY KEY (product_id, tag_id),
+    INDEX idx_tag (tag_id),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (tag_id) REFERENCES tags(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品标签关联表';
+
+-- Assign products to categories
+ALTER TABLE products
+    ADD COLUMN category_id BIGINT COMMENT '商品分类ID' AFTER accepted_wallets,
+    ADD INDEX idx_category (category_id),
+    ADD FOREIGN KEY (category_id) REFERENCES categories(id);
@@ -0,0 +1,12 @@
+-- Add per-locale names and descriptions to products
+ALTER TABLE products
+    ADD COLUMN name_zh VARCHAR(200) COMMENT '商品中文名称' AFTER name,
+    ADD COLUMN name_en VARCHAR(200) COMMENT '商品英文名称' AFTER name_zh,
+    ADD COLUMN short_description_zh VARCHAR(500) COMMENT '中文简介' AFTER name_en,
+    ADD COLUMN short_description_en VARCHAR(500) COMMENT '英文简介' AFTER short_description_zh,
+    ADD COLUMN description_zh TEXT COMMENT '中文详细描述（Markdown）' AFTER short_description_en,
+    ADD COLUMN description_en TEXT COMMENT '英文详细描述（Markdown）' AFTER description_zh,
+    MODIFY COLUMN name VARCHAR(200) NOT NULL COMMENT '商品显示名称（中文优先）';
+
+-- Existing names mix both languages; keep them as the Chinese name until edited
+UPDATE products SET name_zh = name WHERE name_zh IS NULL;
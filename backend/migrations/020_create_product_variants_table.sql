@@ -0,0 +1,22 @@
+-- Create product_variants table
+CREATE TABLE IF NOT EXISTS product_variants (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL COMMENT '商品ID',
+    label VARCHAR(200) NOT NULL COMMENT '规格名称（属性值组合）',
+    attributes JSON COMMENT '规格属性，如尺码、颜色',
+    points_override INT COMMENT '规格积分价格，为空时使用商品价格',
+    stock_quantity INT DEFAULT 0 COMMENT '规格库存',
+    status ENUM('active', 'inactive') DEFAULT 'active' COMMENT '状态',
+    sort_order INT DEFAULT 0 COMMENT '排序',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_product (product_id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品规格表';
+
+-- Record the redeemed variant on orders
+ALTER TABLE redemption_orders
+    ADD COLUMN variant_id BIGINT COMMENT '规格ID' AFTER product_name,
+    ADD COLUMN variant_label VARCHAR(200) COMMENT '兑换时的规格名称快照' AFTER variant_id,
+    ADD INDEX idx_variant (variant_id),
+    ADD FOREIGN KEY (variant_id) REFERENCES product_variants(id);
@@ -0,0 +1,17 @@
+-- Create product_images table
+CREATE TABLE IF NOT EXISTS product_images (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL COMMENT '商品ID',
+    storage_key VARCHAR(255) NOT NULL COMMENT '原图存储键',
+    thumbnail_key VARCHAR(255) NOT NULL COMMENT '缩略图存储键',
+    url VARCHAR(500) NOT NULL COMMENT '原图访问地址',
+    thumbnail_url VARCHAR(500) NOT NULL COMMENT '缩略图访问地址',
+    content_type VARCHAR(50) NOT NULL COMMENT '图片类型',
+    size_bytes BIGINT NOT NULL COMMENT '文件大小（字节）',
+    width INT NOT NULL COMMENT '宽度（像素）',
+    height INT NOT NULL COMMENT '高度（像素）',
+    sort_order INT DEFAULT 0 COMMENT '排序，第一张为商品主图',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_product (product_id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品图片表';
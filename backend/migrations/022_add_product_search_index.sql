@@ -0,0 +1,6 @@
+-- Add FULLTEXT index for catalog keyword search
+-- The ngram parser splits text into character pairs so Chinese names can be searched (MySQL 5.7.6+)
+ALTER TABLE products
+    ADD FULLTEXT INDEX idx_products_search
+    (name_zh, name_en, short_description_zh, short_description_en, description_zh, description_en)
+    WITH PARSER ngram COMMENT '商品关键词检索';
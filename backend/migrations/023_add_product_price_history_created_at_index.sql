@@ -0,0 +1,3 @@
+-- Index price history by time for the catalog price change report
+ALTER TABLE product_price_history
+    ADD INDEX idx_product_price_history_created_at (created_at);
@@ -0,0 +1,24 @@
+-- Create scheduled_price_changes table
+CREATE TABLE IF NOT EXISTS scheduled_price_changes (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL COMMENT '商品ID',
+    points_required INT NOT NULL COMMENT '生效后的所需积分',
+    starts_at TIMESTAMP NOT NULL COMMENT '生效时间',
+    ends_at TIMESTAMP NULL COMMENT '结束时间，为空表示永久调价',
+    revert_points INT COMMENT '生效前的积分，结束时恢复',
+    status ENUM('pending', 'active', 'completed', 'cancelled', 'missed') DEFAULT 'pending' COMMENT '状态',
+    note VARCHAR(500) COMMENT '备注',
+    error VARCHAR(500) COMMENT '最近一次执行失败原因',
+    operator_id BIGINT NOT NULL COMMENT '创建人ID',
+    applied_at TIMESTAMP NULL COMMENT '生效执行时间',
+    reverted_at TIMESTAMP NULL COMMENT '恢复执行时间',
+    cancelled_at TIMESTAMP NULL COMMENT '取消时间',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_product (product_id),
+    INDEX idx_starts_at (starts_at),
+    INDEX idx_ends_at (ends_at),
+    INDEX idx_status (status),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (operator_id) REFERENCES users(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='商品定时调价表';
@@ -0,0 +1,8 @@
+-- Add availability windows for limited-time products
+ALTER TABLE products
+    ADD COLUMN available_from TIMESTAMP NULL COMMENT '上架时间，之前不在商品目录中显示' AFTER status,
+    ADD COLUMN available_until TIMESTAMP NULL COMMENT '下架时间，之后不在商品目录中显示' AFTER available_from,
+    ADD COLUMN published_at TIMESTAMP NULL COMMENT '定时任务最近一次自动上架时间' AFTER available_until,
+    ADD COLUMN unpublished_at TIMESTAMP NULL COMMENT '定时任务最近一次自动下架时间' AFTER published_at,
+    ADD INDEX idx_available_from (available_from),
+    ADD INDEX idx_available_until (available_until);
@@ -0,0 +1,23 @@
+-- Create inventory_movements table
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    product_id BIGINT NOT NULL COMMENT '商品ID',
+    variant_id BIGINT COMMENT '规格ID，为空表示商品本身',
+    variant_label VARCHAR(200) COMMENT '规格名称快照',
+    movement_type ENUM('receive', 'redeem', 'adjust', 'return', 'writeoff') NOT NULL COMMENT '类型：入库、兑换、调整、退回、报损',
+    quantity INT NOT NULL COMMENT '变动数量（正数增加，负数减少）',
+    stock_after INT NOT NULL COMMENT '变动后库存（规格变动时为规格库存）',
+    product_stock_after INT NOT NULL COMMENT '变动后商品总库存',
+    operator_id BIGINT COMMENT '操作人ID（员工兑换时为空）',
+    related_order_id BIGINT COMMENT '关联订单ID',
+    note VARCHAR(500) COMMENT '备注',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_product (product_id),
+    INDEX idx_variant (variant_id),
+    INDEX idx_related_order (related_order_id),
+    INDEX idx_created_at (created_at),
+    FOREIGN KEY (product_id) REFERENCES products(id),
+    FOREIGN KEY (variant_id) REFERENCES product_variants(id),
+    FOREIGN KEY (operator_id) REFERENCES users(id),
+    FOREIGN KEY (related_order_id) REFERENCES redemption_orders(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='库存变动记录表';
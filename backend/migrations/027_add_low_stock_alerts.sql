@@ -0,0 +1,17 @@
+-- Add per-product low-stock thresholds and in-app notifications for admins
+ALTER TABLE products
+    ADD COLUMN low_stock_threshold INT NOT NULL DEFAULT 0 COMMENT '库存预警阈值，库存降至该值时通知管理员' AFTER stock_quantity;
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id BIGINT NOT NULL COMMENT '接收人ID',
+    type VARCHAR(50) NOT NULL COMMENT '通知类型：low_stock 库存不足',
+    title VARCHAR(200) NOT NULL COMMENT '标题（按接收人语言生成）',
+    body VARCHAR(1000) COMMENT '内容',
+    product_id BIGINT COMMENT '相关商品ID',
+    read_at TIMESTAMP NULL COMMENT '已读时间，为空表示未读',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_notifications_user_read (user_id, read_at),
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (product_id) REFERENCES products(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='站内通知表';
@@ -0,0 +1,11 @@
+-- Record manual deactivations so the availability job does not re-activate those products
+ALTER TABLE products
+    ADD COLUMN deactivated_at TIMESTAMP NULL COMMENT '管理员最近一次手动下架时间，定时任务不会重新上架' AFTER unpublished_at;
+
+-- Products already active inside an open window count as published for that window
+UPDATE products
+SET published_at = available_from
+WHERE status = 'active'
+  AND available_from IS NOT NULL
+  AND available_from <= NOW()
+  AND (published_at IS NULL OR published_at < available_from);
@@ -0,0 +1,6 @@
+-- Create data_migrations table
+-- Records one-time data fixes applied by the application on startup (see database.Migrate)
+CREATE TABLE IF NOT EXISTS data_migrations (
+    name VARCHAR(100) PRIMARY KEY COMMENT '数据修复名称，如 seal_points_ledger',
+    applied_at TIMESTAMP NOT NULL COMMENT '执行时间'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='一次性数据修复记录表';
@@ -0,0 +1,10 @@
+-- Create campaign_categories table
+-- A campaign targeting a category also applies to products in its subcategories
+CREATE TABLE IF NOT EXISTS campaign_categories (
+    campaign_id BIGINT NOT NULL COMMENT '活动ID',
+    category_id BIGINT NOT NULL COMMENT '分类ID（含其子分类下的商品）',
+    PRIMARY KEY (campaign_id, category_id),
+    INDEX idx_category (category_id),
+    FOREIGN KEY (campaign_id) REFERENCES campaigns(id),
+    FOREIGN KEY (category_id) REFERENCES categories(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='活动分类关联表';
@@ -0,0 +1,3 @@
+-- Store the original Content-Type of idempotent responses so replays match it
+ALTER TABLE idempotency_keys
+    ADD COLUMN response_content_type VARCHAR(255) NOT NULL DEFAULT '' COMMENT '响应内容类型' AFTER response_status;